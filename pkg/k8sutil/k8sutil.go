@@ -25,71 +25,84 @@ ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
 package k8sutil
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/upmc-enterprises/kong-operator/pkg/kongadmin"
+	"github.com/upmc-enterprises/kong-operator/pkg/resources"
 	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
 
-	k8serrors "k8s.io/client-go/pkg/api/errors"
-	"k8s.io/client-go/pkg/fields"
-	"k8s.io/client-go/pkg/util/intstr"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/client-go/kubernetes"
+	batchType "k8s.io/client-go/kubernetes/typed/batch/v1"
 	coreType "k8s.io/client-go/kubernetes/typed/core/v1"
 	extensionsType "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
-	"k8s.io/client-go/pkg/api"
-	"k8s.io/client-go/pkg/api/unversioned"
-	"k8s.io/client-go/pkg/api/v1"
-	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
-	"k8s.io/client-go/pkg/runtime"
-	"k8s.io/client-go/pkg/runtime/serializer"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-var (
-	tprName = "kong-cluster.enterprises.upmc.com"
-)
-
 const (
 	kongProxyServiceName   = "kong-proxy"
 	kongAdminServiceName   = "kong-admin"
 	kongDeploymentName     = "kong"
 	kongPostgresSecretName = "kong-postgres"
+
+	crdEstablishTimeout = 30 * time.Second
+	crdPollInterval     = time.Second
+
+	defaultProxyLoadBalancerSourceRange = "0.0.0.0/0"
 )
 
 // KubeInterface abstracts the kubernetes client
 type KubeInterface interface {
 	Services(namespace string) coreType.ServiceInterface
-	ThirdPartyResources() extensionsType.ThirdPartyResourceInterface
 	Deployments(namespace string) extensionsType.DeploymentInterface
 	ReplicaSets(namespace string) extensionsType.ReplicaSetInterface
 	Secrets(namespace string) coreType.SecretInterface
+	Jobs(namespace string) batchType.JobInterface
 }
 
 // K8sutil defines the kube object
 type K8sutil struct {
-	Config     *rest.Config
-	TprClient  *rest.RESTClient
-	Kclient    KubeInterface
-	MasterHost string
+	Config       *rest.Config
+	CrdClient    *rest.RESTClient
+	ApiExtClient apiextensionsclient.Interface
+	Kclient      KubeInterface
+	Resources    *resources.Orchestrator
+	MasterHost   string
 }
 
 // New creates a new instance of k8sutil
 func New(kubeCfgFile, masterHost string) (*K8sutil, error) {
 
-	client, tprclient, err := newKubeClient(kubeCfgFile)
+	client, crdClient, apiExtClient, err := newKubeClient(kubeCfgFile)
 
 	if err != nil {
 		logrus.Fatalf("Could not init Kubernetes client! [%s]", err)
 	}
 
 	k := &K8sutil{
-		Kclient:    client,
-		TprClient:  tprclient,
-		MasterHost: masterHost,
+		Kclient:      client,
+		CrdClient:    crdClient,
+		ApiExtClient: apiExtClient,
+		Resources:    resources.NewOrchestrator(client),
+		MasterHost:   masterHost,
 	}
 
 	return k, nil
@@ -105,33 +118,19 @@ func buildConfig(kubeCfgFile string) (*rest.Config, error) {
 	return rest.InClusterConfig()
 }
 
-func configureTPRClient(config *rest.Config) {
-	groupversion := unversioned.GroupVersion{
-		Group:   "enterprises.upmc.com",
-		Version: "v1",
-	}
+func configureCrdClient(config *rest.Config) {
+	groupversion := tpr.SchemeGroupVersion
 
 	config.GroupVersion = &groupversion
 	config.APIPath = "/apis"
 	config.ContentType = runtime.ContentTypeJSON
-	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: api.Codecs}
-
-	schemeBuilder := runtime.NewSchemeBuilder(
-		func(scheme *runtime.Scheme) error {
-			scheme.AddKnownTypes(
-				unversioned.GroupVersion{Group: "enterprises.upmc.com", Version: "v1"},
-				&tpr.KongCluster{},
-				&tpr.KongClusterList{},
-				&api.ListOptions{},
-				&api.DeleteOptions{},
-			)
-			return nil
-		})
 
-	schemeBuilder.AddToScheme(api.Scheme)
+	scheme := runtime.NewScheme()
+	tpr.AddToScheme(scheme)
+	config.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: serializer.NewCodecFactory(scheme)}
 }
 
-func newKubeClient(kubeCfgFile string) (KubeInterface, *rest.RESTClient, error) {
+func newKubeClient(kubeCfgFile string) (kubernetes.Interface, *rest.RESTClient, apiextensionsclient.Interface, error) {
 
 	// Create the client config. Use kubeconfig if given, otherwise assume in-cluster.
 	Config, err := buildConfig(kubeCfgFile)
@@ -144,51 +143,86 @@ func newKubeClient(kubeCfgFile string) (KubeInterface, *rest.RESTClient, error)
 		panic(err)
 	}
 
-	// make a new config for our extension's API group, using the first config as a baseline
-	var tprconfig *rest.Config
-	tprconfig = Config
+	apiExtClient, err := apiextensionsclient.NewForConfig(Config)
+	if err != nil {
+		panic(err)
+	}
 
-	configureTPRClient(tprconfig)
+	// reuse Config itself for our CRD's API group: configureCrdClient mutates
+	// it in place, after the clientsets above have been built from it
+	var crdConfig *rest.Config
+	crdConfig = Config
 
-	tprclient, err := rest.RESTClientFor(tprconfig)
+	configureCrdClient(crdConfig)
+
+	crdClient, err := rest.RESTClientFor(crdConfig)
 	if err != nil {
 		logrus.Error(err.Error())
-		logrus.Error("can not get client to TPR")
+		logrus.Error("can not get client to KongCluster CRD")
 		os.Exit(2)
 	}
 
-	return client, tprclient, nil
+	return client, crdClient, apiExtClient, nil
 }
 
-// CreateKubernetesThirdPartyResource checks if Kong TPR exists. If not, create
-func (k *K8sutil) CreateKubernetesThirdPartyResource() error {
+// EnsureKongClusterCRD checks if the KongCluster CRD exists and registers it if not,
+// waiting for the apiserver to establish the new type before returning.
+func (k *K8sutil) EnsureKongClusterCRD() error {
+
+	_, err := k.ApiExtClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(tpr.CRDFullName, metav1.GetOptions{})
+	if err == nil {
+		logrus.Infof("SKIPPING: CRD already exists %s", tpr.CRDFullName)
+		return nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
 
-	tpr, err := k.Kclient.ThirdPartyResources().Get(tprName)
+	crd := tpr.CustomResourceDefinition()
+
+	_, err = k.ApiExtClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
 	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			tpr := &v1beta1.ThirdPartyResource{
-				ObjectMeta: v1.ObjectMeta{
-					Name: tprName,
-				},
-				Versions: []v1beta1.APIVersion{
-					{Name: "v1"},
-				},
-				Description: "Managed kong clusters",
-			}
+		logrus.Error("Could not create KongCluster CRD: ", err)
+		return err
+	}
+
+	logrus.Infof("CREATED CRD: %s", tpr.CRDFullName)
+
+	return k.waitForCRDEstablished()
+}
+
+// waitForCRDEstablished polls the CRD's status conditions until NamesAccepted
+// and Established are both True, or crdEstablishTimeout elapses.
+func (k *K8sutil) waitForCRDEstablished() error {
+	deadline := time.Now().Add(crdEstablishTimeout)
+
+	for time.Now().Before(deadline) {
+		crd, err := k.ApiExtClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(tpr.CRDFullName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
 
-			_, err := k.Kclient.ThirdPartyResources().Create(tpr)
-			if err != nil {
-				panic(err)
+		established := false
+		namesAccepted := false
+
+		for _, cond := range crd.Status.Conditions {
+			switch cond.Type {
+			case apiextensionsv1beta1.Established:
+				established = cond.Status == apiextensionsv1beta1.ConditionTrue
+			case apiextensionsv1beta1.NamesAccepted:
+				namesAccepted = cond.Status == apiextensionsv1beta1.ConditionTrue
 			}
-			logrus.Infof("CREATED TPR: %#v", tpr.ObjectMeta.Name)
-		} else {
-			panic(err)
 		}
-	} else {
-		logrus.Infof("SKIPPING: already exists %#v", tpr.ObjectMeta.Name)
+
+		if established && namesAccepted {
+			return nil
+		}
+
+		time.Sleep(crdPollInterval)
 	}
 
-	return nil
+	return fmt.Errorf("timed out waiting for CRD %s to be established", tpr.CRDFullName)
 }
 
 // GetKongClusters returns a list of custom clusters defined
@@ -197,7 +231,7 @@ func (k *K8sutil) GetKongClusters() ([]tpr.KongCluster, error) {
 	var err error
 
 	for {
-		err = k.TprClient.Get().Resource("KongClusters").Do().Into(&kongList)
+		err = k.CrdClient.Get().Resource(tpr.CRDKindPlural).Do().Into(&kongList)
 
 		if err != nil {
 			logrus.Error("error getting kong clusters")
@@ -211,27 +245,49 @@ func (k *K8sutil) GetKongClusters() ([]tpr.KongCluster, error) {
 	return kongList.Items, nil
 }
 
-// MonitorKongEvents watches for new or removed clusters
+// MonitorKongEvents watches for new or removed clusters via an informer
+// built on a ListWatch over CrdClient, the KongCluster CRD's REST client; no
+// generated typed clientset or informer is involved
 func (k *K8sutil) MonitorKongEvents(stopchan chan struct{}) (<-chan *tpr.KongCluster, <-chan error) {
 	events := make(chan *tpr.KongCluster)
 	errc := make(chan error, 1)
 
-	source := cache.NewListWatchFromClient(k.TprClient, "kongclusters", api.NamespaceAll, fields.Everything())
+	source := cache.NewListWatchFromClient(k.CrdClient, tpr.CRDKindPlural, metav1.NamespaceAll, fields.Everything())
 
 	createAddHandler := func(obj interface{}) {
-		event := obj.(*tpr.KongCluster)
+		// Deep-copy before mutating: obj is the informer's cached object,
+		// shared with every other handler and the cache itself.
+		event := obj.(*tpr.KongCluster).DeepCopy()
 		event.Type = "ADDED"
+		k.syncAdminConfig(event, errc)
 		events <- event
 	}
 	createDeleteHandler := func(obj interface{}) {
-		event := obj.(*tpr.KongCluster)
+		event := obj.(*tpr.KongCluster).DeepCopy()
 		event.Type = "DELETED"
+		if err := kongadmin.NewReconciler(event.Namespace).Teardown(event); err != nil {
+			logrus.Error("Could not tear down kong admin config: ", err)
+			errc <- err
+		}
 		events <- event
 	}
 
 	updateHandler := func(old interface{}, obj interface{}) {
-		event := obj.(*tpr.KongCluster)
+		event := obj.(*tpr.KongCluster).DeepCopy()
 		event.Type = "MODIFIED"
+
+		// The KongCluster CRD has the status subresource enabled, so
+		// Generation only advances on a spec change; every
+		// UpdateKongClusterStatus call at the end of syncAdminConfig comes
+		// back through this same informer as a MODIFIED event with an
+		// unchanged Generation. Reconciling that event would just write the
+		// same status again, forever. Skip it.
+		if oldCR, ok := old.(*tpr.KongCluster); ok && oldCR.Generation == event.Generation {
+			events <- event
+			return
+		}
+
+		k.syncAdminConfig(event, errc)
 		events <- event
 	}
 
@@ -250,112 +306,124 @@ func (k *K8sutil) MonitorKongEvents(stopchan chan struct{}) (<-chan *tpr.KongClu
 	return events, errc
 }
 
-// CreateKongProxyService creates the kong proxy service
-func (k *K8sutil) CreateKongProxyService(namespace string) error {
+// syncAdminConfig reconciles the kong-proxy/kong-admin Services, the kong
+// Deployment and the declarative admin config (services, routes, plugins,
+// consumers, upstreams, certificates) for a KongCluster, and persists the
+// result on its status, including the standard Progressing/Available/
+// Degraded conditions
+func (k *K8sutil) syncAdminConfig(cr *tpr.KongCluster, errc chan<- error) {
+	cr.Status.SetCondition(tpr.ConditionProgressing, v1.ConditionTrue, "Reconciling", "Applying KongCluster spec")
 
-	// Check if service exists
-	svc, err := k.Kclient.Services(namespace).Get(kongProxyServiceName)
+	var errs []string
 
-	// Service missing, create
-	if len(svc.Name) == 0 {
-		logrus.Infof("%s not found, creating...", kongProxyServiceName)
+	if err := k.CreateKongWorkload(cr); err != nil {
+		errs = append(errs, fmt.Sprintf("workload: %s", err))
+	}
 
-		clientSvc := &v1.Service{
-			ObjectMeta: v1.ObjectMeta{
-				Name: kongProxyServiceName,
-				Labels: map[string]string{
-					"name": kongProxyServiceName,
-				},
-			},
-			Spec: v1.ServiceSpec{
-				Selector: map[string]string{
-					"app": "kong",
-				},
-				Ports: []v1.ServicePort{
-					v1.ServicePort{
-						Name:       "kong-proxy",
-						Port:       80,
-						TargetPort: intstr.FromInt(8000),
-						Protocol:   "TCP",
-					},
-					v1.ServicePort{
-						Name:       "kong-proxy-ssl",
-						Port:       443,
-						TargetPort: intstr.FromInt(8443),
-						Protocol:   "TCP",
-					},
-				},
-				Type: v1.ServiceTypeLoadBalancer,
-				LoadBalancerSourceRanges: []string{
-					"0.0.0.0/0",
-				},
-			},
+	cr.Status.AdminObjects = kongadmin.NewReconciler(cr.Namespace).Sync(cr)
+	for key, s := range cr.Status.AdminObjects {
+		if !s.Applied {
+			errs = append(errs, fmt.Sprintf("%s: %s", key, s.Error))
 		}
+	}
 
-		_, err := k.Kclient.Services(namespace).Create(clientSvc)
-
-		if err != nil {
-			logrus.Error("Could not create proxy service", err)
-			return err
-		}
-	} else if err != nil {
-		logrus.Error("Could not get proxy service! ", err)
-		return err
+	cr.Status.SetCondition(tpr.ConditionProgressing, v1.ConditionFalse, "Reconciled", "Finished applying KongCluster spec")
+	if len(errs) > 0 {
+		message := strings.Join(errs, "; ")
+		cr.Status.SetCondition(tpr.ConditionDegraded, v1.ConditionTrue, "ReconcileError", message)
+		cr.Status.SetCondition(tpr.ConditionAvailable, v1.ConditionFalse, "ReconcileError", message)
+	} else {
+		cr.Status.SetCondition(tpr.ConditionDegraded, v1.ConditionFalse, "ReconcileSucceeded", "")
+		cr.Status.SetCondition(tpr.ConditionAvailable, v1.ConditionTrue, "ReconcileSucceeded", "")
 	}
 
-	return nil
+	if err := k.UpdateKongClusterStatus(cr); err != nil {
+		logrus.Error("Could not update kong cluster status: ", err)
+		errc <- err
+	}
 }
 
-// CreateKongAdminService creates the kong proxy service
-func (k *K8sutil) CreateKongAdminService(namespace string) error {
-
-	// Check if service exists
-	svc, err := k.Kclient.Services(namespace).Get(kongAdminServiceName)
+// UpdateKongClusterStatus persists the status subresource of a KongCluster
+func (k *K8sutil) UpdateKongClusterStatus(cr *tpr.KongCluster) error {
+	return k.CrdClient.Put().
+		Namespace(cr.Namespace).
+		Resource(tpr.CRDKindPlural).
+		Name(cr.Name).
+		SubResource("status").
+		Body(cr).
+		Do().
+		Error()
+}
 
-	// Service missing, create
-	if len(svc.Name) == 0 {
-		logrus.Infof("%s not found, creating...", kongAdminServiceName)
+func kongProxyServiceSpec(namespace string, sourceRanges []string) *v1.Service {
+	if len(sourceRanges) == 0 {
+		sourceRanges = []string{defaultProxyLoadBalancerSourceRange}
+	}
 
-		clientSvc := &v1.Service{
-			ObjectMeta: v1.ObjectMeta{
-				Name: kongAdminServiceName,
-				Labels: map[string]string{
-					"name": kongAdminServiceName,
-				},
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongProxyServiceName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongProxyServiceName,
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{
+				"app": "kong",
 			},
-			Spec: v1.ServiceSpec{
-				Selector: map[string]string{
-					"app": "kong",
+			Ports: []v1.ServicePort{
+				v1.ServicePort{
+					Name:       "kong-proxy",
+					Port:       80,
+					TargetPort: intstr.FromInt(8000),
+					Protocol:   "TCP",
 				},
-				Ports: []v1.ServicePort{
-					v1.ServicePort{
-						Name:       "kong-admin",
-						Port:       8444,
-						TargetPort: intstr.FromInt(8444),
-						Protocol:   "TCP",
-					},
+				v1.ServicePort{
+					Name:       "kong-proxy-ssl",
+					Port:       443,
+					TargetPort: intstr.FromInt(8443),
+					Protocol:   "TCP",
 				},
-				Type: v1.ServiceTypeClusterIP,
 			},
-		}
-
-		_, err := k.Kclient.Services(namespace).Create(clientSvc)
-
-		if err != nil {
-			logrus.Error("Could not create admin service: ", err)
-			return err
-		}
-	} else if err != nil {
-		logrus.Error("Could not get admin service: ", err)
-		return err
+			Type:                     v1.ServiceTypeLoadBalancer,
+			LoadBalancerSourceRanges: sourceRanges,
+		},
 	}
+}
 
-	return nil
+func kongAdminServiceSpec(namespace string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongAdminServiceName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongAdminServiceName,
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{
+				"app": "kong",
+			},
+			Ports: []v1.ServicePort{
+				v1.ServicePort{
+					Name:       "kong-admin",
+					Port:       8444,
+					TargetPort: intstr.FromInt(8444),
+					Protocol:   "TCP",
+				},
+			},
+			Type: v1.ServiceTypeClusterIP,
+		},
+	}
 }
 
-// DeleteProxyService creates the kong proxy service
+// DeleteProxyService deletes the kong proxy service
 func (k *K8sutil) DeleteProxyService(namespace string) error {
-	err := k.Kclient.Services(namespace).Delete(kongProxyServiceName, &v1.DeleteOptions{})
+	owner := &tpr.KongCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+	spec := kongProxyServiceSpec(namespace, nil)
+
+	err := k.Resources.DeleteOne(context.Background(), owner, resources.Resource{Name: kongProxyServiceName, Kind: "Service", Spec: spec})
 	if err != nil {
 		logrus.Error("Could not delete service "+kongProxyServiceName+":", err)
 	} else {
@@ -365,9 +433,12 @@ func (k *K8sutil) DeleteProxyService(namespace string) error {
 	return err
 }
 
-// DeleteAdminService creates the kong admin service
+// DeleteAdminService deletes the kong admin service
 func (k *K8sutil) DeleteAdminService(namespace string) error {
-	err := k.Kclient.Services(namespace).Delete(kongAdminServiceName, &v1.DeleteOptions{})
+	owner := &tpr.KongCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}
+	spec := kongAdminServiceSpec(namespace)
+
+	err := k.Resources.DeleteOne(context.Background(), owner, resources.Resource{Name: kongAdminServiceName, Kind: "Service", Spec: spec})
 	if err != nil {
 		logrus.Error("Could not delete service "+kongAdminServiceName+":", err)
 	} else {
@@ -377,158 +448,138 @@ func (k *K8sutil) DeleteAdminService(namespace string) error {
 	return err
 }
 
-// CreateKongDeployment creates the kong deployment
-func (k *K8sutil) CreateKongDeployment(baseImage string, replicas *int32, namespace string) error {
-
-	// Check if deployment exists
-	deployment, err := k.Kclient.Deployments(namespace).Get(kongDeploymentName)
-
-	if len(deployment.Name) == 0 {
-		logrus.Infof("%s not found, creating...", kongDeploymentName)
-
-		deployment := &v1beta1.Deployment{
-			ObjectMeta: v1.ObjectMeta{
-				Name: kongDeploymentName,
-				Labels: map[string]string{
-					"name": kongDeploymentName,
-				},
+func kongDeploymentSpec(baseImage string, replicas *int32, namespace string) *v1beta1.Deployment {
+	return &v1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongDeploymentName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongDeploymentName,
 			},
-			Spec: v1beta1.DeploymentSpec{
-				Replicas: replicas,
-				Template: v1.PodTemplateSpec{
-					ObjectMeta: v1.ObjectMeta{
-						Labels: map[string]string{
-							"app":  "kong",
-							"name": kongDeploymentName,
-						},
+		},
+		Spec: v1beta1.DeploymentSpec{
+			Replicas: replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":  "kong",
+						"name": kongDeploymentName,
 					},
-					Spec: v1.PodSpec{
-						Containers: []v1.Container{
-							v1.Container{
-								Name:  kongDeploymentName,
-								Image: baseImage,
-								Env: []v1.EnvVar{
-									v1.EnvVar{
-										Name: "NAMESPACE",
-										ValueFrom: &v1.EnvVarSource{
-											FieldRef: &v1.ObjectFieldSelector{
-												FieldPath: "metadata.namespace",
-											},
-										},
-									},
-									v1.EnvVar{
-										Name: "KONG_PG_USER",
-										ValueFrom: &v1.EnvVarSource{
-											SecretKeyRef: &v1.SecretKeySelector{
-												Key: "KONG_PG_USER",
-												LocalObjectReference: v1.LocalObjectReference{
-													Name: kongPostgresSecretName,
-												},
-											},
-										},
-									},
-									v1.EnvVar{
-										Name: "KONG_PG_PASSWORD",
-										ValueFrom: &v1.EnvVarSource{
-											SecretKeyRef: &v1.SecretKeySelector{
-												Key: "KONG_PG_PASSWORD",
-												LocalObjectReference: v1.LocalObjectReference{
-													Name: kongPostgresSecretName,
-												},
-											},
-										},
-									},
-									v1.EnvVar{
-										Name: "KONG_PG_HOST",
-										ValueFrom: &v1.EnvVarSource{
-											SecretKeyRef: &v1.SecretKeySelector{
-												Key: "KONG_PG_HOST",
-												LocalObjectReference: v1.LocalObjectReference{
-													Name: kongPostgresSecretName,
-												},
-											},
-										},
-									},
-									v1.EnvVar{
-										Name: "KONG_PG_DATABASE",
-										ValueFrom: &v1.EnvVarSource{
-											SecretKeyRef: &v1.SecretKeySelector{
-												Key: "KONG_PG_DATABASE",
-												LocalObjectReference: v1.LocalObjectReference{
-													Name: kongPostgresSecretName,
-												},
-											},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  kongDeploymentName,
+							Image: baseImage,
+							Env: []v1.EnvVar{
+								v1.EnvVar{
+									Name: "NAMESPACE",
+									ValueFrom: &v1.EnvVarSource{
+										FieldRef: &v1.ObjectFieldSelector{
+											FieldPath: "metadata.namespace",
 										},
 									},
-									v1.EnvVar{
-										Name: "KONG_HOST_IP",
-										ValueFrom: &v1.EnvVarSource{
-											FieldRef: &v1.ObjectFieldSelector{
-												APIVersion: "v1",
-												FieldPath:  "status.podIP",
-											},
+								},
+								kongPgEnvVar("KONG_PG_USER"),
+								kongPgEnvVar("KONG_PG_PASSWORD"),
+								kongPgEnvVar("KONG_PG_HOST"),
+								kongPgEnvVar("KONG_PG_DATABASE"),
+								v1.EnvVar{
+									Name: "KONG_HOST_IP",
+									ValueFrom: &v1.EnvVarSource{
+										FieldRef: &v1.ObjectFieldSelector{
+											APIVersion: "v1",
+											FieldPath:  "status.podIP",
 										},
 									},
-									v1.EnvVar{
-										Name:  "KONG_ADMIN_LISTEN", // Disable non-tls
-										Value: "127.0.0.1:8001",
-									},
 								},
-								Command: []string{
-									"/bin/sh", "-c",
-									"KONG_CLUSTER_ADVERTISE=$(KONG_HOST_IP):7946 KONG_NGINX_DAEMON='off' kong start",
+								v1.EnvVar{
+									Name:  "KONG_ADMIN_LISTEN", // Serve TLS on the port the kong-admin Service and kongadmin.Client expect
+									Value: "0.0.0.0:8444 ssl",
 								},
-								Ports: []v1.ContainerPort{
-									v1.ContainerPort{
-										Name:          "proxy",
-										ContainerPort: 8000,
-										Protocol:      v1.ProtocolTCP,
-									},
-									v1.ContainerPort{
-										Name:          "proxy-ssl",
-										ContainerPort: 8443,
-										Protocol:      v1.ProtocolTCP,
-									},
-									v1.ContainerPort{
-										Name:          "surf-tcp",
-										ContainerPort: 7946,
-										Protocol:      v1.ProtocolTCP,
-									},
-									v1.ContainerPort{
-										Name:          "surf-udp",
-										ContainerPort: 7946,
-										Protocol:      v1.ProtocolUDP,
-									},
+							},
+							Command: []string{
+								"/bin/sh", "-c",
+								"KONG_CLUSTER_ADVERTISE=$(KONG_HOST_IP):7946 KONG_NGINX_DAEMON='off' kong start",
+							},
+							Ports: []v1.ContainerPort{
+								v1.ContainerPort{
+									Name:          "proxy",
+									ContainerPort: 8000,
+									Protocol:      v1.ProtocolTCP,
+								},
+								v1.ContainerPort{
+									Name:          "proxy-ssl",
+									ContainerPort: 8443,
+									Protocol:      v1.ProtocolTCP,
+								},
+								v1.ContainerPort{
+									Name:          "admin",
+									ContainerPort: 8444,
+									Protocol:      v1.ProtocolTCP,
+								},
+								v1.ContainerPort{
+									Name:          "surf-tcp",
+									ContainerPort: 7946,
+									Protocol:      v1.ProtocolTCP,
+								},
+								v1.ContainerPort{
+									Name:          "surf-udp",
+									ContainerPort: 7946,
+									Protocol:      v1.ProtocolUDP,
 								},
 							},
 						},
 					},
 				},
 			},
-		}
+		},
+	}
+}
+
+// CreateKongWorkload reconciles the kong Deployment and its proxy/admin
+// Services, first making sure the kong-postgres Secret the Deployment reads
+// its KONG_PG_* env vars from is ready, any pending credential rotation has
+// been applied, and the migrations Job has completed against it. Each
+// resource is reconciled with its own EnsureOne call,
+// Deployment before the Services that route to it (matching
+// ServicePlugin.DependsOn()), rather than through Resources.Apply: Apply's
+// rollback-on-failure deletes every already-applied resource in the chain,
+// which is right for one-shot provisioning but wrong here, since this runs
+// on every reconcile for the life of the cluster — a transient failure
+// patching the admin Service would otherwise delete an already-healthy
+// Deployment and proxy Service as "rollback".
+func (k *K8sutil) CreateKongWorkload(cr *tpr.KongCluster) error {
+	if err := k.EnsurePostgresSecret(cr); err != nil {
+		return err
+	}
 
-		_, err := k.Kclient.Deployments(namespace).Create(deployment)
+	if err := k.RotatePostgresCredentials(cr); err != nil {
+		return err
+	}
 
-		if err != nil {
-			logrus.Error("Could not create kong deployment: ", err)
-			return err
-		}
-	} else {
-		if err != nil {
-			logrus.Error("Could not get kong deployment! ", err)
+	if !cr.Status.Postgres.MigrationsApplied {
+		if err := k.RunKongMigrations(cr); err != nil {
 			return err
 		}
+	}
 
-		//scale replicas?
-		if deployment.Spec.Replicas != replicas {
-			deployment.Spec.Replicas = replicas
+	deploymentSpec := kongDeploymentSpec(cr.Spec.BaseImage, cr.Spec.Replicas, cr.Namespace)
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongDeploymentName, Kind: "Deployment", Spec: deploymentSpec}); err != nil {
+		logrus.Error("Could not apply kong deployment: ", err)
+		return err
+	}
 
-			_, err := k.Kclient.Deployments(namespace).Update(deployment)
+	proxySpec := kongProxyServiceSpec(cr.Namespace, cr.Spec.ProxyLoadBalancerSourceRanges)
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongProxyServiceName, Kind: "Service", Spec: proxySpec}); err != nil {
+		logrus.Error("Could not apply kong proxy service: ", err)
+		return err
+	}
 
-			if err != nil {
-				logrus.Error("Could not scale deployment: ", err)
-			}
-		}
+	adminSpec := kongAdminServiceSpec(cr.Namespace)
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongAdminServiceName, Kind: "Service", Spec: adminSpec}); err != nil {
+		logrus.Error("Could not apply kong admin service: ", err)
+		return err
 	}
 
 	return nil
@@ -555,7 +606,7 @@ func (k *K8sutil) DeleteKongDeployment(namespace string) error {
 		logrus.Infof("Scaled deployment: %s to zero", deployment.Name)
 	}
 
-	err = k.Kclient.Deployments(namespace).Delete(deployment.Name, &v1.DeleteOptions{})
+	err = k.Kclient.Deployments(namespace).Delete(deployment.Name, &metav1.DeleteOptions{})
 
 	if err != nil {
 		logrus.Errorf("Could not delete deployments: %s ", deployment.Name)
@@ -567,14 +618,14 @@ func (k *K8sutil) DeleteKongDeployment(namespace string) error {
 	time.Sleep(2 * time.Second)
 
 	// Get list of ReplicaSets
-	replicaSets, err := k.Kclient.ReplicaSets(namespace).List(v1.ListOptions{LabelSelector: "app=kong,name=kong"})
+	replicaSets, err := k.Kclient.ReplicaSets(namespace).List(metav1.ListOptions{LabelSelector: "app=kong,name=kong"})
 
 	if err != nil {
 		logrus.Error("Could not get replica sets! ", err)
 	}
 
 	for _, replicaSet := range replicaSets.Items {
-		err := k.Kclient.ReplicaSets(namespace).Delete(replicaSet.Name, &v1.DeleteOptions{})
+		err := k.Kclient.ReplicaSets(namespace).Delete(replicaSet.Name, &metav1.DeleteOptions{})
 
 		if err != nil {
 			logrus.Errorf("Could not delete replica sets: %s ", replicaSet.Name)
@@ -0,0 +1,460 @@
+package k8sutil
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/upmc-enterprises/kong-operator/pkg/resources"
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	kongPostgresStatefulSetName   = "kong-postgres"
+	kongPostgresServiceName       = "kong-postgres"
+	kongMigrationsJobName         = "kong-migrations-bootstrap"
+	kongPostgresRotationJobPrefix = "kong-postgres-rotate-"
+
+	// rotatedAtAnnotation marks the kong-postgres Secret, and in turn the Kong
+	// Deployment's pod template, with the time a credential rotation was requested
+	rotatedAtAnnotation = "kong-operator/rotated-at"
+
+	defaultPostgresImage       = "postgres:9.6"
+	defaultPostgresStorageSize = "1Gi"
+
+	jobCompleteTimeout = 2 * time.Minute
+)
+
+func generateRandomPassword(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func kongPgEnvVar(key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: key,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				Key: key,
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: kongPostgresSecretName,
+				},
+			},
+		},
+	}
+}
+
+func kongPostgresSecretSpec(namespace, password string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongPostgresSecretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongPostgresSecretName,
+			},
+		},
+		StringData: map[string]string{
+			"KONG_PG_USER":     "kong",
+			"KONG_PG_PASSWORD": password,
+			"KONG_PG_HOST":     kongPostgresServiceName,
+			"KONG_PG_DATABASE": "kong",
+		},
+	}
+}
+
+func kongPostgresHeadlessServiceSpec(namespace string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongPostgresServiceName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongPostgresServiceName,
+			},
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Selector: map[string]string{
+				"app": kongPostgresStatefulSetName,
+			},
+			Ports: []v1.ServicePort{
+				v1.ServicePort{
+					Name:       "postgres",
+					Port:       5432,
+					TargetPort: intstr.FromInt(5432),
+					Protocol:   "TCP",
+				},
+			},
+		},
+	}
+}
+
+func kongPostgresStatefulSetSpec(namespace, image, storageSize string) *appsv1beta1.StatefulSet {
+	if image == "" {
+		image = defaultPostgresImage
+	}
+	if storageSize == "" {
+		storageSize = defaultPostgresStorageSize
+	}
+
+	var replicas int32 = 1
+
+	return &appsv1beta1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongPostgresStatefulSetName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"name": kongPostgresStatefulSetName,
+			},
+		},
+		Spec: appsv1beta1.StatefulSetSpec{
+			ServiceName: kongPostgresServiceName,
+			Replicas:    &replicas,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":  kongPostgresStatefulSetName,
+						"name": kongPostgresStatefulSetName,
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "postgres",
+							Image: image,
+							Env: []v1.EnvVar{
+								v1.EnvVar{Name: "POSTGRES_USER", ValueFrom: kongPgEnvVar("KONG_PG_USER").ValueFrom},
+								v1.EnvVar{Name: "POSTGRES_PASSWORD", ValueFrom: kongPgEnvVar("KONG_PG_PASSWORD").ValueFrom},
+								v1.EnvVar{Name: "POSTGRES_DB", ValueFrom: kongPgEnvVar("KONG_PG_DATABASE").ValueFrom},
+							},
+							Ports: []v1.ContainerPort{
+								v1.ContainerPort{
+									Name:          "postgres",
+									ContainerPort: 5432,
+									Protocol:      v1.ProtocolTCP,
+								},
+							},
+							VolumeMounts: []v1.VolumeMount{
+								v1.VolumeMount{
+									Name:      "data",
+									MountPath: "/var/lib/postgresql/data",
+								},
+							},
+						},
+					},
+				},
+			},
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{
+				v1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "data",
+					},
+					Spec: v1.PersistentVolumeClaimSpec{
+						AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{
+								v1.ResourceStorage: resource.MustParse(storageSize),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kongMigrationsJobSpec(cr *tpr.KongCluster) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongMigrationsJobName,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"name": kongMigrationsJobName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"name": kongMigrationsJobName,
+					},
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyOnFailure,
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "kong-migrations",
+							Image: cr.Spec.BaseImage,
+							Env: []v1.EnvVar{
+								kongPgEnvVar("KONG_PG_USER"),
+								kongPgEnvVar("KONG_PG_PASSWORD"),
+								kongPgEnvVar("KONG_PG_HOST"),
+								kongPgEnvVar("KONG_PG_DATABASE"),
+							},
+							Command: []string{"kong", "migrations", "bootstrap"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kongPostgresRotationJobName(rotatedAt string) string {
+	sum := sha1.Sum([]byte(rotatedAt))
+	return fmt.Sprintf("%s%x", kongPostgresRotationJobPrefix, sum[:4])
+}
+
+func kongPostgresRotationJobSpec(cr *tpr.KongCluster, user, newPassword, rotatedAt string) *batchv1.Job {
+	name := kongPostgresRotationJobName(rotatedAt)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				"name": kongPostgresStatefulSetName + "-rotate",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"name": kongPostgresStatefulSetName + "-rotate",
+					},
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyOnFailure,
+					Containers: []v1.Container{
+						v1.Container{
+							Name:  "rotate-postgres-credentials",
+							Image: defaultPostgresImage,
+							Env: []v1.EnvVar{
+								kongPgEnvVar("KONG_PG_HOST"),
+								kongPgEnvVar("KONG_PG_DATABASE"),
+								v1.EnvVar{Name: "PGUSER", Value: "postgres"},
+							},
+							Command: []string{
+								"psql", "-h", "$(KONG_PG_HOST)", "-d", "$(KONG_PG_DATABASE)",
+								"-c", fmt.Sprintf("ALTER USER %s WITH PASSWORD '%s'", user, newPassword),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// EnsurePostgresSecret makes sure the kong-postgres Secret the Kong
+// Deployment reads its KONG_PG_* env vars from exists, along with its
+// headless Service and backing Postgres StatefulSet. If the Secret is
+// missing and the KongCluster spec has Postgres.Manage set, a strong random
+// password is generated and all three are created; otherwise a missing
+// Secret is reported as an error so the operator stops before Kong pods
+// crashloop against a database that isn't there. Once the Secret exists,
+// every subsequent call with Postgres.Manage set still reconciles the
+// Service and StatefulSet
+// through Resources.EnsureOne so they heal if deleted or drifted, without
+// regenerating the password or recreating the Secret.
+func (k *K8sutil) EnsurePostgresSecret(cr *tpr.KongCluster) error {
+	namespace := cr.Namespace
+
+	_, err := k.Kclient.Secrets(namespace).Get(kongPostgresSecretName, metav1.GetOptions{})
+	if err == nil {
+		cr.Status.Postgres.SecretReady = true
+		if !cr.Spec.Postgres.Manage {
+			return nil
+		}
+		return k.ensurePostgresWorkload(cr)
+	}
+	if !k8serrors.IsNotFound(err) {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	if !cr.Spec.Postgres.Manage {
+		err := fmt.Errorf("postgres secret %s/%s does not exist and spec.postgres.manage is false", namespace, kongPostgresSecretName)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	password, err := generateRandomPassword(32)
+	if err != nil {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongPostgresSecretName, Kind: "Secret", Spec: kongPostgresSecretSpec(namespace, password)}); err != nil {
+		logrus.Error("Could not create postgres secret: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	cr.Status.Postgres.SecretReady = true
+	return k.ensurePostgresWorkload(cr)
+}
+
+// ensurePostgresWorkload reconciles the kong-postgres headless Service and
+// backing StatefulSet, healing either one if it is later deleted or drifts
+func (k *K8sutil) ensurePostgresWorkload(cr *tpr.KongCluster) error {
+	namespace := cr.Namespace
+
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongPostgresServiceName, Kind: "Service", Spec: kongPostgresHeadlessServiceSpec(namespace)}); err != nil {
+		logrus.Error("Could not reconcile postgres headless service: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	spec := kongPostgresStatefulSetSpec(namespace, cr.Spec.Postgres.Image, cr.Spec.Postgres.StorageSize)
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongPostgresStatefulSetName, Kind: "StatefulSet", Spec: spec}); err != nil {
+		logrus.Error("Could not reconcile postgres statefulset: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	cr.Status.Postgres.Error = ""
+	return nil
+}
+
+// RunKongMigrations runs the one-shot `kong migrations bootstrap` Job and
+// waits for it to complete, gated on the kong-postgres Secret already being
+// ready. It must succeed before the Kong Deployment is created.
+func (k *K8sutil) RunKongMigrations(cr *tpr.KongCluster) error {
+	if !cr.Status.Postgres.SecretReady {
+		return fmt.Errorf("postgres secret for %s/%s is not ready yet", cr.Namespace, cr.Name)
+	}
+
+	spec := kongMigrationsJobSpec(cr)
+
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: kongMigrationsJobName, Kind: "Job", Spec: spec}); err != nil {
+		logrus.Error("Could not run kong migrations bootstrap job: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	if err := k.waitForJobComplete(cr.Namespace, kongMigrationsJobName); err != nil {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	cr.Status.Postgres.MigrationsApplied = true
+	cr.Status.Postgres.Error = ""
+	return nil
+}
+
+// RotatePostgresCredentials checks the kong-postgres Secret's rotated-at
+// annotation and, if it changed since the last reconcile, issues an ALTER
+// USER via a rotation Job, updates the Secret's password and rolls the Kong
+// Deployment so its pods pick up the new credentials.
+func (k *K8sutil) RotatePostgresCredentials(cr *tpr.KongCluster) error {
+	namespace := cr.Namespace
+
+	secret, err := k.Kclient.Secrets(namespace).Get(kongPostgresSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	rotatedAt := secret.Annotations[rotatedAtAnnotation]
+	if rotatedAt == "" || rotatedAt == cr.Status.Postgres.RotatedAt {
+		return nil
+	}
+
+	newPassword, err := generateRandomPassword(32)
+	if err != nil {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	jobName := kongPostgresRotationJobName(rotatedAt)
+	jobSpec := kongPostgresRotationJobSpec(cr, string(secret.Data["KONG_PG_USER"]), newPassword, rotatedAt)
+
+	if _, err := k.Resources.EnsureOne(context.Background(), cr, resources.Resource{Name: jobName, Kind: "Job", Spec: jobSpec}); err != nil {
+		logrus.Error("Could not run postgres credential rotation job: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	if err := k.waitForJobComplete(namespace, jobName); err != nil {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	secret.Data["KONG_PG_PASSWORD"] = []byte(newPassword)
+	if _, err := k.Kclient.Secrets(namespace).Update(secret); err != nil {
+		logrus.Error("Could not update rotated postgres secret: ", err)
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	if err := k.rollKongDeployment(namespace, rotatedAt); err != nil {
+		cr.Status.Postgres.Error = err.Error()
+		return err
+	}
+
+	cr.Status.Postgres.RotatedAt = rotatedAt
+	cr.Status.Postgres.Error = ""
+	return nil
+}
+
+// rollKongDeployment patches the Kong Deployment's pod template with
+// rotatedAt so the resulting spec change triggers a rolling restart, without
+// the operator needing to delete pods itself.
+func (k *K8sutil) rollKongDeployment(namespace, rotatedAt string) error {
+	deployment, err := k.Kclient.Deployments(namespace).Get(kongDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if deployment.Spec.Template.ObjectMeta.Annotations == nil {
+		deployment.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.ObjectMeta.Annotations[rotatedAtAnnotation] = rotatedAt
+
+	if _, err := k.Kclient.Deployments(namespace).Update(deployment); err != nil {
+		logrus.Error("Could not roll kong deployment after credential rotation: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// waitForJobComplete polls a Job's status conditions until it reports
+// Complete, Failed, or jobCompleteTimeout elapses.
+func (k *K8sutil) waitForJobComplete(namespace, name string) error {
+	deadline := time.Now().Add(jobCompleteTimeout)
+
+	for time.Now().Before(deadline) {
+		job, err := k.Kclient.Jobs(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchv1.JobComplete && cond.Status == v1.ConditionTrue {
+				return nil
+			}
+			if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+				return fmt.Errorf("job %s/%s failed: %s", namespace, name, cond.Message)
+			}
+		}
+
+		time.Sleep(crdPollInterval)
+	}
+
+	return fmt.Errorf("timed out waiting for job %s/%s to complete", namespace, name)
+}
@@ -0,0 +1,130 @@
+// Package kongadmin talks to Kong's admin API to reconcile the declarative
+// services/routes/plugins/consumers/upstreams/certificates described on a
+// KongCluster spec.
+package kongadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const adminPort = 8444
+
+// Object is a generic Kong admin API object, keyed by its JSON field names
+type Object map[string]interface{}
+
+// Client is a thin HTTP client for the in-cluster kong-admin service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient builds a Client pointed at the kong-admin Service in namespace
+func NewClient(namespace string) *Client {
+	return &Client{
+		baseURL: fmt.Sprintf("https://kong-admin.%s.svc:%d", namespace, adminPort),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// List returns every object of the given kind (e.g. "services", "routes")
+func (c *Client) List(kind string) ([]Object, error) {
+	var all []Object
+	offset := ""
+
+	for {
+		url := fmt.Sprintf("%s/%s", c.baseURL, kind)
+		if offset != "" {
+			url = fmt.Sprintf("%s?offset=%s", url, offset)
+		}
+
+		var page struct {
+			Data []Object `json:"data"`
+			Next string   `json:"next"`
+		}
+
+		if err := c.do(http.MethodGet, url, nil, &page); err != nil {
+			return nil, err
+		}
+
+		all = append(all, page.Data...)
+
+		if page.Next == "" {
+			break
+		}
+		offset = page.Next
+	}
+
+	return all, nil
+}
+
+// Create creates a new object of the given kind
+func (c *Client) Create(kind string, obj Object) (Object, error) {
+	url := fmt.Sprintf("%s/%s", c.baseURL, kind)
+
+	var created Object
+	if err := c.do(http.MethodPost, url, obj, &created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Update updates an existing object of the given kind by id
+func (c *Client) Update(kind, id string, obj Object) error {
+	url := fmt.Sprintf("%s/%s/%s", c.baseURL, kind, id)
+	return c.do(http.MethodPatch, url, obj, nil)
+}
+
+// Delete removes an object of the given kind by id
+func (c *Client) Delete(kind, id string) error {
+	url := fmt.Sprintf("%s/%s/%s", c.baseURL, kind, id)
+	return c.do(http.MethodDelete, url, nil, nil)
+}
+
+func (c *Client) do(method, url string, body interface{}, out interface{}) error {
+	var reqBody *bytes.Reader
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("kong admin API %s %s returned %d: %s", method, url, resp.StatusCode, string(respBody))
+	}
+
+	if out != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, out)
+	}
+
+	return nil
+}
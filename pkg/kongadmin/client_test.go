@@ -0,0 +1,107 @@
+package kongadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	return &Client{baseURL: server.URL, httpClient: server.Client()}, server.Close
+}
+
+func TestClientList(t *testing.T) {
+	pages := []struct {
+		Data []Object `json:"data"`
+		Next string   `json:"next"`
+	}{
+		{Data: []Object{{"name": "a"}}, Next: "page2"},
+		{Data: []Object{{"name": "b"}}, Next: ""},
+	}
+
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := pages[0]
+		if r.URL.Query().Get("offset") == "page2" {
+			page = pages[1]
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	defer cleanup()
+
+	objs, err := client.List("services")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objs) != 2 || objs[0]["name"] != "a" || objs[1]["name"] != "b" {
+		t.Fatalf("expected both pages to be concatenated, got %v", objs)
+	}
+}
+
+func TestClientCreate(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		var body Object
+		json.NewDecoder(r.Body).Decode(&body)
+		body["id"] = "generated-id"
+		json.NewEncoder(w).Encode(body)
+	})
+	defer cleanup()
+
+	created, err := client.Create("services", Object{"name": "svc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created["id"] != "generated-id" {
+		t.Fatalf("expected server-assigned id, got %v", created)
+	}
+}
+
+func TestClientUpdate(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/services/abc" {
+			t.Fatalf("expected /services/abc, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	if err := client.Update("services", "abc", Object{"name": "svc"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientDelete(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("expected DELETE, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer cleanup()
+
+	if err := client.Delete("services", "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientErrorStatus(t *testing.T) {
+	client, cleanup := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"schema violation"}`)
+	})
+	defer cleanup()
+
+	_, err := client.Create("services", Object{"name": "svc"})
+	if err == nil {
+		t.Fatal("expected an error for a non-2xx response")
+	}
+}
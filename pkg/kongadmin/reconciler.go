@@ -0,0 +1,221 @@
+package kongadmin
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// managedByTag marks every object the operator creates so it can later tell
+// objects it owns apart from ones created out-of-band, and prune orphans
+// safely on delete.
+func managedByTag(crName string) string {
+	return "managed-by=kong-operator:" + crName
+}
+
+// kinds enumerates the admin API collections the reconciler manages, in the
+// order they should be synced. Teardown runs the same list in reverse.
+var kinds = []string{"services", "routes", "plugins", "consumers", "upstreams", "certificates"}
+
+// Reconciler drives a KongCluster's declarative admin config (services,
+// routes, plugins, consumers, upstreams, certificates) to match its spec.
+type Reconciler struct {
+	client *Client
+}
+
+// NewReconciler builds a Reconciler that talks to the kong-admin Service in namespace
+func NewReconciler(namespace string) *Reconciler {
+	return &Reconciler{client: NewClient(namespace)}
+}
+
+// Sync reconciles every declared object against the admin API and returns
+// the per-object status to persist on KongCluster.Status
+func (r *Reconciler) Sync(cr *tpr.KongCluster) map[string]tpr.KongAdminObjectStatus {
+	status := map[string]tpr.KongAdminObjectStatus{}
+	tag := managedByTag(cr.Name)
+
+	for _, kind := range kinds {
+		for key, s := range r.syncKind(tag, kind, desiredForKind(cr, kind)) {
+			status[key] = s
+		}
+	}
+
+	return status
+}
+
+// Teardown deletes every object tagged as owned by cr, in reverse dependency order
+func (r *Reconciler) Teardown(cr *tpr.KongCluster) error {
+	tag := managedByTag(cr.Name)
+
+	for i := len(kinds) - 1; i >= 0; i-- {
+		kind := kinds[i]
+
+		actual, err := r.client.List(kind)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range actual {
+			if !hasTag(obj, tag) {
+				continue
+			}
+			if err := r.client.Delete(kind, idOf(obj)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) syncKind(tag, kind string, desired []tpr.KongAdminObject) map[string]tpr.KongAdminObjectStatus {
+	status := map[string]tpr.KongAdminObjectStatus{}
+
+	actual, err := r.client.List(kind)
+	if err != nil {
+		for _, d := range desired {
+			status[statusKey(kind, d.Name)] = errorStatus(kind, d.Name, err)
+		}
+		return status
+	}
+
+	actualByName := map[string]Object{}
+	for _, obj := range actual {
+		if hasTag(obj, tag) {
+			actualByName[nameOf(obj)] = obj
+		}
+	}
+
+	desiredNames := map[string]bool{}
+
+	for _, d := range desired {
+		desiredNames[d.Name] = true
+		desiredObj := toObject(d, tag)
+
+		if existing, ok := actualByName[d.Name]; ok {
+			if !configEqual(existing, desiredObj) {
+				err := r.client.Update(kind, idOf(existing), desiredObj)
+				status[statusKey(kind, d.Name)] = syncStatus(kind, d.Name, err)
+			} else {
+				status[statusKey(kind, d.Name)] = syncStatus(kind, d.Name, nil)
+			}
+			continue
+		}
+
+		_, err := r.client.Create(kind, desiredObj)
+		status[statusKey(kind, d.Name)] = syncStatus(kind, d.Name, err)
+	}
+
+	// Prune tagged objects that are no longer declared
+	for name, obj := range actualByName {
+		if desiredNames[name] {
+			continue
+		}
+		if err := r.client.Delete(kind, idOf(obj)); err != nil {
+			status[statusKey(kind, name)] = errorStatus(kind, name, err)
+		}
+	}
+
+	return status
+}
+
+func desiredForKind(cr *tpr.KongCluster, kind string) []tpr.KongAdminObject {
+	switch kind {
+	case "services":
+		return cr.Spec.Services
+	case "routes":
+		return cr.Spec.Routes
+	case "plugins":
+		return cr.Spec.Plugins
+	case "consumers":
+		return cr.Spec.Consumers
+	case "upstreams":
+		return cr.Spec.Upstreams
+	case "certificates":
+		return cr.Spec.Certificates
+	default:
+		return nil
+	}
+}
+
+func toObject(d tpr.KongAdminObject, tag string) Object {
+	obj := Object{
+		"name": d.Name,
+		"tags": []string{tag},
+	}
+	for k, v := range d.Config {
+		obj[k] = v
+	}
+	return obj
+}
+
+func hasTag(obj Object, tag string) bool {
+	tags, ok := obj["tags"].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func nameOf(obj Object) string {
+	name, _ := obj["name"].(string)
+	return name
+}
+
+func idOf(obj Object) string {
+	id, _ := obj["id"].(string)
+	return id
+}
+
+// configEqual compares the fields we manage; it ignores server-assigned
+// fields like id/created_at that will never match the desired object.
+// Values are compared with reflect.DeepEqual rather than !=, since fields
+// other than plain strings (booleans, numbers, arrays, nested objects)
+// decode from the admin API's JSON response as bool/float64/[]interface{}/
+// map[string]interface{}; != panics on the slice and map values, while
+// DeepEqual compares them element by element. Either way the dynamic types
+// must match for two values to be equal.
+func configEqual(actual, desired Object) bool {
+	for k, v := range desired {
+		if k == "tags" {
+			continue
+		}
+		if !reflect.DeepEqual(actual[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+func statusKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+func syncStatus(kind, name string, err error) tpr.KongAdminObjectStatus {
+	if err != nil {
+		return errorStatus(kind, name, err)
+	}
+	return tpr.KongAdminObjectStatus{
+		Kind:         kind,
+		Name:         name,
+		Applied:      true,
+		LastSyncTime: metav1.Now(),
+	}
+}
+
+func errorStatus(kind, name string, err error) tpr.KongAdminObjectStatus {
+	return tpr.KongAdminObjectStatus{
+		Kind:         kind,
+		Name:         name,
+		Applied:      false,
+		Error:        err.Error(),
+		LastSyncTime: metav1.Now(),
+	}
+}
@@ -0,0 +1,235 @@
+package kongadmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// fakeAdminAPI is an in-memory stand-in for the Kong admin API's "services"
+// collection, enough to exercise syncKind's create/update/prune branches
+// against real HTTP request/response plumbing rather than mocking Client.
+type fakeAdminAPI struct {
+	objects map[string]Object
+	nextID  int
+}
+
+func newFakeAdminAPI(seed ...Object) *fakeAdminAPI {
+	api := &fakeAdminAPI{objects: map[string]Object{}}
+	for _, o := range seed {
+		api.nextID++
+		id := o["id"].(string)
+		api.objects[id] = o
+	}
+	return api
+}
+
+func (a *fakeAdminAPI) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			var data []Object
+			for _, o := range a.objects {
+				data = append(data, o)
+			}
+			json.NewEncoder(w).Encode(struct {
+				Data []Object `json:"data"`
+				Next string   `json:"next"`
+			}{Data: data})
+		case r.Method == http.MethodPost:
+			var obj Object
+			json.NewDecoder(r.Body).Decode(&obj)
+			a.nextID++
+			id := string(rune('a' + a.nextID))
+			obj["id"] = id
+			a.objects[id] = obj
+			json.NewEncoder(w).Encode(obj)
+		case r.Method == http.MethodPatch:
+			id := r.URL.Path[len("/services/"):]
+			var obj Object
+			json.NewDecoder(r.Body).Decode(&obj)
+			obj["id"] = id
+			a.objects[id] = obj
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodDelete:
+			id := r.URL.Path[len("/services/"):]
+			delete(a.objects, id)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func newTestReconciler(t *testing.T, api *fakeAdminAPI) (*Reconciler, func()) {
+	t.Helper()
+	server := httptest.NewServer(api.handler())
+	r := &Reconciler{client: &Client{baseURL: server.URL, httpClient: server.Client()}}
+	return r, server.Close
+}
+
+func TestSyncKindCreatesMissingObjects(t *testing.T) {
+	api := newFakeAdminAPI()
+	r, closeServer := newTestReconciler(t, api)
+	defer closeServer()
+
+	desired := []tpr.KongAdminObject{
+		{Name: "users-service", Config: map[string]interface{}{"url": "http://users"}},
+	}
+
+	status := r.syncKind("managed-by=kong-operator:test", "services", desired)
+
+	s, ok := status["services/users-service"]
+	if !ok || !s.Applied {
+		t.Fatalf("expected users-service to be applied, got %+v", status)
+	}
+	if len(api.objects) != 1 {
+		t.Fatalf("expected the object to be created on the fake admin API, got %d objects", len(api.objects))
+	}
+}
+
+func TestSyncKindUpdatesDriftedObjects(t *testing.T) {
+	tag := "managed-by=kong-operator:test"
+	api := newFakeAdminAPI(Object{
+		"id":   "a1",
+		"name": "users-service",
+		"url":  "http://old",
+		"tags": []interface{}{tag},
+	})
+	r, closeServer := newTestReconciler(t, api)
+	defer closeServer()
+
+	desired := []tpr.KongAdminObject{
+		{Name: "users-service", Config: map[string]interface{}{"url": "http://new"}},
+	}
+
+	status := r.syncKind(tag, "services", desired)
+
+	if s := status["services/users-service"]; !s.Applied {
+		t.Fatalf("expected update to succeed, got %+v", s)
+	}
+	if api.objects["a1"]["url"] != "http://new" {
+		t.Fatalf("expected drift to be corrected, got %v", api.objects["a1"])
+	}
+}
+
+func TestSyncKindSkipsObjectsWithNoDrift(t *testing.T) {
+	tag := "managed-by=kong-operator:test"
+	api := newFakeAdminAPI(Object{
+		"id":        "a1",
+		"name":      "users-service",
+		"url":       "http://same",
+		"strip_uri": true,
+		"tags":      []interface{}{tag},
+	})
+	r, closeServer := newTestReconciler(t, api)
+	defer closeServer()
+
+	desired := []tpr.KongAdminObject{
+		{Name: "users-service", Config: map[string]interface{}{"url": "http://same", "strip_uri": true}},
+	}
+
+	// Mark the stored object: a PATCH replaces it and a DELETE removes it,
+	// either of which drops the marker. No drift means neither Update nor
+	// Delete should be called.
+	api.objects["a1"]["__untouched"] = true
+	status := r.syncKind(tag, "services", desired)
+
+	if s := status["services/users-service"]; !s.Applied {
+		t.Fatalf("expected the unchanged object to still be reported as applied, got %+v", s)
+	}
+	if _, ok := api.objects["a1"]["__untouched"]; !ok {
+		t.Fatal("expected syncKind to make no write request when nothing drifted")
+	}
+}
+
+func TestSyncKindPrunesUndeclaredObjects(t *testing.T) {
+	tag := "managed-by=kong-operator:test"
+	api := newFakeAdminAPI(Object{
+		"id":   "a1",
+		"name": "orphaned-service",
+		"tags": []interface{}{tag},
+	})
+	r, closeServer := newTestReconciler(t, api)
+	defer closeServer()
+
+	status := r.syncKind(tag, "services", nil)
+
+	if len(api.objects) != 0 {
+		t.Fatalf("expected the undeclared tagged object to be pruned, got %v", api.objects)
+	}
+	if len(status) != 0 {
+		t.Fatalf("expected no status entries for a pure prune, got %+v", status)
+	}
+}
+
+func TestSyncKindLeavesUnmanagedObjectsAlone(t *testing.T) {
+	api := newFakeAdminAPI(Object{
+		"id":   "a1",
+		"name": "hand-created-service",
+		"tags": []interface{}{"some-other-owner"},
+	})
+	r, closeServer := newTestReconciler(t, api)
+	defer closeServer()
+
+	r.syncKind("managed-by=kong-operator:test", "services", nil)
+
+	if len(api.objects) != 1 {
+		t.Fatalf("expected the object tagged for another owner to survive pruning, got %v", api.objects)
+	}
+}
+
+func TestConfigEqual(t *testing.T) {
+	cases := []struct {
+		name    string
+		actual  Object
+		desired Object
+		want    bool
+	}{
+		{
+			name:    "matching string",
+			actual:  Object{"url": "http://a"},
+			desired: Object{"url": "http://a"},
+			want:    true,
+		},
+		{
+			name:    "differing string",
+			actual:  Object{"url": "http://a"},
+			desired: Object{"url": "http://b"},
+			want:    false,
+		},
+		{
+			name:    "json-decoded bool matches",
+			actual:  Object{"strip_uri": true},
+			desired: Object{"strip_uri": true},
+			want:    true,
+		},
+		{
+			name:    "json-decoded number matches",
+			actual:  Object{"retries": float64(5)},
+			desired: Object{"retries": float64(5)},
+			want:    true,
+		},
+		{
+			name:    "json-decoded nested object matches",
+			actual:  Object{"headers": map[string]interface{}{"x-id": []interface{}{"1"}}},
+			desired: Object{"headers": map[string]interface{}{"x-id": []interface{}{"1"}}},
+			want:    true,
+		},
+		{
+			name:    "tags are ignored",
+			actual:  Object{"tags": []interface{}{"a"}},
+			desired: Object{"tags": []interface{}{"b"}},
+			want:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := configEqual(c.actual, c.desired); got != c.want {
+				t.Fatalf("configEqual(%v, %v) = %v, want %v", c.actual, c.desired, got, c.want)
+			}
+		})
+	}
+}
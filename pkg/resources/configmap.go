@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// ConfigMapPlugin reconciles a single ConfigMap spec
+type ConfigMapPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewConfigMapPlugin builds a ConfigMapPlugin backed by client
+func NewConfigMapPlugin(client kubernetes.Interface) *ConfigMapPlugin {
+	return &ConfigMapPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *ConfigMapPlugin) Kind() string { return "ConfigMap" }
+
+// DependsOn satisfies ResourcePlugin; ConfigMaps have no dependencies of their own
+func (p *ConfigMapPlugin) DependsOn() []string { return nil }
+
+func (p *ConfigMapPlugin) asConfigMap(spec interface{}) (*v1.ConfigMap, error) {
+	cm, ok := spec.(*v1.ConfigMap)
+	if !ok {
+		return nil, fmt.Errorf("ConfigMapPlugin: unexpected spec type %T", spec)
+	}
+	return cm, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *ConfigMapPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	cm, err := p.asConfigMap(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.CoreV1().ConfigMaps(owner.Namespace).Create(cm)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *ConfigMapPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	cm, err := p.asConfigMap(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.CoreV1().ConfigMaps(owner.Namespace).Get(cm.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin
+func (p *ConfigMapPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	cm, err := p.asConfigMap(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.CoreV1().ConfigMaps(owner.Namespace).Get(cm.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	cm.ResourceVersion = existing.ResourceVersion
+
+	updated, err := p.client.CoreV1().ConfigMaps(owner.Namespace).Update(cm)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *ConfigMapPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	cm, err := p.asConfigMap(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.CoreV1().ConfigMaps(owner.Namespace).Delete(cm.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(value string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong-config", Namespace: owner.Namespace},
+			Data:       map[string]string{"KONG_LOG_LEVEL": value},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewConfigMapPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec("notice"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-config" {
+			t.Fatalf("expected name kong-config, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("notice"))
+		p := NewConfigMapPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("notice")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("notice"))
+		p := NewConfigMapPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("debug")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.CoreV1().ConfigMaps(owner.Namespace).Get("kong-config", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Data["KONG_LOG_LEVEL"] != "debug" {
+			t.Fatalf("expected drift to be corrected, got %s", updated.Data["KONG_LOG_LEVEL"])
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewConfigMapPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec("notice")); err != nil {
+			t.Fatalf("expected delete of missing configmap to be a no-op, got: %v", err)
+		}
+	})
+}
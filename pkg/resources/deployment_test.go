@@ -0,0 +1,119 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentPlugin(t *testing.T) {
+	owner := testOwner()
+
+	var one int32 = 1
+	newSpec := func(replicas int32) *v1beta1.Deployment {
+		return &v1beta1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: owner.Namespace},
+			Spec:       v1beta1.DeploymentSpec{Replicas: &replicas},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewDeploymentPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec(one))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong" {
+			t.Fatalf("expected name kong, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(one))
+		p := NewDeploymentPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(one)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("replicas-only change is applied after a real create", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewDeploymentPlugin(client)
+
+		if _, err := p.Create(context.Background(), owner, newSpec(one)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var three int32 = 3
+		if _, err := p.Update(context.Background(), owner, newSpec(three)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.ExtensionsV1beta1().Deployments(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated.Spec.Replicas != three {
+			t.Fatalf("expected replicas-only drift to be corrected to 3, got %d", *updated.Spec.Replicas)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(one))
+		p := NewDeploymentPlugin(client)
+
+		var three int32 = 3
+		if _, err := p.Update(context.Background(), owner, newSpec(three)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.ExtensionsV1beta1().Deployments(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated.Spec.Replicas != three {
+			t.Fatalf("expected drift to be corrected to 3 replicas, got %d", *updated.Spec.Replicas)
+		}
+	})
+
+	t.Run("skips patch when no drift", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewDeploymentPlugin(client)
+
+		if _, err := p.Create(context.Background(), owner, newSpec(one)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		before, err := client.ExtensionsV1beta1().Deployments(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := p.Update(context.Background(), owner, newSpec(one)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		after, err := client.ExtensionsV1beta1().Deployments(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if before.ResourceVersion != after.ResourceVersion {
+			t.Fatalf("expected no-op update to leave the Deployment untouched, resource version changed from %s to %s", before.ResourceVersion, after.ResourceVersion)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewDeploymentPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec(one)); err != nil {
+			t.Fatalf("expected delete of missing deployment to be a no-op, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// HPAPlugin reconciles a single HorizontalPodAutoscaler spec
+type HPAPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewHPAPlugin builds an HPAPlugin backed by client
+func NewHPAPlugin(client kubernetes.Interface) *HPAPlugin {
+	return &HPAPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *HPAPlugin) Kind() string { return "HorizontalPodAutoscaler" }
+
+// DependsOn satisfies ResourcePlugin; HPAs scale a Deployment
+func (p *HPAPlugin) DependsOn() []string { return []string{"Deployment"} }
+
+func (p *HPAPlugin) asHPA(spec interface{}) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	h, ok := spec.(*autoscalingv1.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, fmt.Errorf("HPAPlugin: unexpected spec type %T", spec)
+	}
+	return h, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *HPAPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	h, err := p.asHPA(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Create(h)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *HPAPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	h, err := p.asHPA(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Get(h.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin
+func (p *HPAPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	h, err := p.asHPA(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Get(h.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	h.ResourceVersion = existing.ResourceVersion
+
+	updated, err := p.client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Update(h)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *HPAPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	h, err := p.asHPA(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Delete(h.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
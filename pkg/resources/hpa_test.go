@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHPAPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(maxReplicas int32) *autoscalingv1.HorizontalPodAutoscaler {
+		return &autoscalingv1.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: owner.Namespace},
+			Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MaxReplicas: maxReplicas,
+				ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+					Kind: "Deployment",
+					Name: "kong",
+				},
+			},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewHPAPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec(5))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong" {
+			t.Fatalf("expected name kong, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(5))
+		p := NewHPAPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(5)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(5))
+		p := NewHPAPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(10)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.AutoscalingV1().HorizontalPodAutoscalers(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Spec.MaxReplicas != 10 {
+			t.Fatalf("expected drift to be corrected to 10, got %d", updated.Spec.MaxReplicas)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewHPAPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec(5)); err != nil {
+			t.Fatalf("expected delete of missing hpa to be a no-op, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/api/extensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// IngressPlugin reconciles a single Ingress spec
+type IngressPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewIngressPlugin builds an IngressPlugin backed by client
+func NewIngressPlugin(client kubernetes.Interface) *IngressPlugin {
+	return &IngressPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *IngressPlugin) Kind() string { return "Ingress" }
+
+// DependsOn satisfies ResourcePlugin; Ingresses route to a Service
+func (p *IngressPlugin) DependsOn() []string { return []string{"Service"} }
+
+func (p *IngressPlugin) asIngress(spec interface{}) (*v1beta1.Ingress, error) {
+	i, ok := spec.(*v1beta1.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("IngressPlugin: unexpected spec type %T", spec)
+	}
+	return i, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *IngressPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	i, err := p.asIngress(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.ExtensionsV1beta1().Ingresses(owner.Namespace).Create(i)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *IngressPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	i, err := p.asIngress(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.ExtensionsV1beta1().Ingresses(owner.Namespace).Get(i.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin
+func (p *IngressPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	i, err := p.asIngress(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.ExtensionsV1beta1().Ingresses(owner.Namespace).Get(i.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	i.ResourceVersion = existing.ResourceVersion
+
+	updated, err := p.client.ExtensionsV1beta1().Ingresses(owner.Namespace).Update(i)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *IngressPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	i, err := p.asIngress(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.ExtensionsV1beta1().Ingresses(owner.Namespace).Delete(i.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
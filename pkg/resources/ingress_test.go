@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIngressPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(host string) *v1beta1.Ingress {
+		return &v1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: owner.Namespace},
+			Spec: v1beta1.IngressSpec{
+				Rules: []v1beta1.IngressRule{{Host: host}},
+			},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewIngressPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec("kong.example.com"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong" {
+			t.Fatalf("expected name kong, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("kong.example.com"))
+		p := NewIngressPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("kong.example.com")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("kong.example.com"))
+		p := NewIngressPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("kong2.example.com")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.ExtensionsV1beta1().Ingresses(owner.Namespace).Get("kong", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Spec.Rules[0].Host != "kong2.example.com" {
+			t.Fatalf("expected drift to be corrected, got %s", updated.Spec.Rules[0].Host)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewIngressPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec("kong.example.com")); err != nil {
+			t.Fatalf("expected delete of missing ingress to be a no-op, got: %v", err)
+		}
+	})
+}
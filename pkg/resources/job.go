@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// JobPlugin reconciles a single batch Job spec. Job specs are immutable once
+// created, so Update never mutates an existing Job; it only confirms one is
+// present and returns its name.
+type JobPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewJobPlugin builds a JobPlugin backed by client
+func NewJobPlugin(client kubernetes.Interface) *JobPlugin {
+	return &JobPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *JobPlugin) Kind() string { return "Job" }
+
+// DependsOn satisfies ResourcePlugin; Jobs run against the credentials in a Secret
+func (p *JobPlugin) DependsOn() []string { return []string{"Secret"} }
+
+func (p *JobPlugin) asJob(spec interface{}) (*batchv1.Job, error) {
+	j, ok := spec.(*batchv1.Job)
+	if !ok {
+		return nil, fmt.Errorf("JobPlugin: unexpected spec type %T", spec)
+	}
+	return j, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *JobPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	j, err := p.asJob(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.BatchV1().Jobs(owner.Namespace).Create(j)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *JobPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	j, err := p.asJob(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.BatchV1().Jobs(owner.Namespace).Get(j.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin. It is a no-op against an already-existing
+// Job, since Job specs can't be mutated once created.
+func (p *JobPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	j, err := p.asJob(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.BatchV1().Jobs(owner.Namespace).Get(j.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return existing.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *JobPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	j, err := p.asJob(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.BatchV1().Jobs(owner.Namespace).Delete(j.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestJobPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func() *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong-migrations-bootstrap", Namespace: owner.Namespace},
+			Spec: batchv1.JobSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						RestartPolicy: v1.RestartPolicyOnFailure,
+						Containers: []v1.Container{
+							{Name: "kong-migrations", Image: "kong:0.13", Command: []string{"kong", "migrations", "bootstrap"}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewJobPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-migrations-bootstrap" {
+			t.Fatalf("expected name kong-migrations-bootstrap, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply is a no-op", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec())
+		p := NewJobPlugin(client)
+
+		name, err := p.Update(context.Background(), owner, newSpec())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-migrations-bootstrap" {
+			t.Fatalf("expected name kong-migrations-bootstrap, got %s", name)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewJobPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec()); err != nil {
+			t.Fatalf("expected delete of missing job to be a no-op, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// Orchestrator applies a set of Resources in DependsOn order, and tears them
+// down in reverse order
+type Orchestrator struct {
+	plugins map[string]ResourcePlugin
+}
+
+// NewOrchestrator builds an Orchestrator with the standard Service,
+// Deployment, Secret, ConfigMap, Ingress, HPA, StatefulSet and Job plugins
+// registered
+func NewOrchestrator(client kubernetes.Interface) *Orchestrator {
+	o := &Orchestrator{plugins: map[string]ResourcePlugin{}}
+
+	for _, p := range []ResourcePlugin{
+		NewSecretPlugin(client),
+		NewConfigMapPlugin(client),
+		NewStatefulSetPlugin(client),
+		NewJobPlugin(client),
+		NewDeploymentPlugin(client),
+		NewServicePlugin(client),
+		NewIngressPlugin(client),
+		NewHPAPlugin(client),
+	} {
+		o.plugins[p.Kind()] = p
+	}
+
+	return o
+}
+
+// Apply creates or updates every resource, in dependency order. If any
+// resource fails, every resource already applied during this call is
+// deleted again before the error is returned.
+func (o *Orchestrator) Apply(ctx context.Context, owner *tpr.KongCluster, rs []Resource) error {
+	ordered, err := o.order(rs)
+	if err != nil {
+		return err
+	}
+
+	var applied []Resource
+
+	for _, r := range ordered {
+		plugin, ok := o.plugins[r.Kind]
+		if !ok {
+			o.rollback(ctx, owner, applied)
+			return fmt.Errorf("no resource plugin registered for kind %q", r.Kind)
+		}
+
+		_, err := plugin.Get(ctx, owner, r.Spec)
+		switch {
+		case err == nil:
+			_, err = plugin.Update(ctx, owner, r.Spec)
+		case k8serrors.IsNotFound(err):
+			_, err = plugin.Create(ctx, owner, r.Spec)
+		}
+
+		if err != nil {
+			logrus.Errorf("Could not apply %s: %s", r, err)
+			o.rollback(ctx, owner, applied)
+			return err
+		}
+
+		applied = append(applied, r)
+	}
+
+	return nil
+}
+
+// EnsureOne creates r if it is missing, or reconciles it towards spec if it
+// already exists, without considering any other resource's dependencies
+func (o *Orchestrator) EnsureOne(ctx context.Context, owner *tpr.KongCluster, r Resource) (string, error) {
+	plugin, ok := o.plugins[r.Kind]
+	if !ok {
+		return "", fmt.Errorf("no resource plugin registered for kind %q", r.Kind)
+	}
+
+	_, err := plugin.Get(ctx, owner, r.Spec)
+	switch {
+	case err == nil:
+		return plugin.Update(ctx, owner, r.Spec)
+	case k8serrors.IsNotFound(err):
+		return plugin.Create(ctx, owner, r.Spec)
+	default:
+		return "", err
+	}
+}
+
+// DeleteOne removes r; a not-found is not an error
+func (o *Orchestrator) DeleteOne(ctx context.Context, owner *tpr.KongCluster, r Resource) error {
+	plugin, ok := o.plugins[r.Kind]
+	if !ok {
+		return fmt.Errorf("no resource plugin registered for kind %q", r.Kind)
+	}
+
+	return plugin.Delete(ctx, owner, r.Spec)
+}
+
+// Teardown deletes every resource, in reverse dependency order
+func (o *Orchestrator) Teardown(ctx context.Context, owner *tpr.KongCluster, rs []Resource) error {
+	ordered, err := o.order(rs)
+	if err != nil {
+		return err
+	}
+
+	for i := len(ordered) - 1; i >= 0; i-- {
+		r := ordered[i]
+
+		plugin, ok := o.plugins[r.Kind]
+		if !ok {
+			return fmt.Errorf("no resource plugin registered for kind %q", r.Kind)
+		}
+
+		if err := plugin.Delete(ctx, owner, r.Spec); err != nil {
+			logrus.Errorf("Could not delete %s: %s", r, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback deletes every already-applied resource in reverse order, best-effort
+func (o *Orchestrator) rollback(ctx context.Context, owner *tpr.KongCluster, applied []Resource) {
+	for i := len(applied) - 1; i >= 0; i-- {
+		r := applied[i]
+
+		plugin, ok := o.plugins[r.Kind]
+		if !ok {
+			continue
+		}
+
+		if err := plugin.Delete(ctx, owner, r.Spec); err != nil {
+			logrus.Errorf("Rollback: could not delete %s: %s", r, err)
+		}
+	}
+}
+
+// order topologically sorts rs so that every resource appears after the
+// resources its Kind depends on. Resources are grouped by Kind and keep their
+// input order within a Kind; Kinds are emitted depth-first, in order of first
+// appearance in rs and then of each plugin's DependsOn list, so Kinds at the
+// same dependency depth do not necessarily keep their input order
+func (o *Orchestrator) order(rs []Resource) ([]Resource, error) {
+	byKind := map[string][]Resource{}
+	for _, r := range rs {
+		byKind[r.Kind] = append(byKind[r.Kind], r)
+	}
+
+	visited := map[string]int{} // 0 = unvisited, 1 = visiting, 2 = done
+	var kindOrder []string
+
+	var visit func(kind string) error
+	visit = func(kind string) error {
+		switch visited[kind] {
+		case 2:
+			return nil
+		case 1:
+			return fmt.Errorf("cycle detected in resource dependencies at %q", kind)
+		}
+
+		visited[kind] = 1
+
+		plugin, ok := o.plugins[kind]
+		if ok {
+			for _, dep := range plugin.DependsOn() {
+				if _, present := byKind[dep]; !present {
+					continue
+				}
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+
+		visited[kind] = 2
+		kindOrder = append(kindOrder, kind)
+		return nil
+	}
+
+	for _, r := range rs {
+		if err := visit(r.Kind); err != nil {
+			return nil, err
+		}
+	}
+
+	var ordered []Resource
+	for _, kind := range kindOrder {
+		ordered = append(ordered, byKind[kind]...)
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOrchestratorApply(t *testing.T) {
+	owner := testOwner()
+	client := fake.NewSimpleClientset()
+	o := NewOrchestrator(client)
+
+	var one int32 = 1
+	rs := []Resource{
+		{Name: "proxy", Kind: "Service", Spec: &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "kong-proxy", Namespace: owner.Namespace}}},
+		{Name: "secret", Kind: "Secret", Spec: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kong-postgres", Namespace: owner.Namespace}}},
+		{Name: "deploy", Kind: "Deployment", Spec: &v1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: owner.Namespace}, Spec: v1beta1.DeploymentSpec{Replicas: &one}}},
+	}
+
+	if err := o.Apply(context.Background(), owner, rs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, r := range rs {
+		plugin := o.plugins[r.Kind]
+		if _, err := plugin.Get(context.Background(), owner, r.Spec); err != nil {
+			t.Fatalf("expected %s to have been applied: %v", r, err)
+		}
+	}
+}
+
+func TestOrchestratorApplyRollsBackOnFailure(t *testing.T) {
+	owner := testOwner()
+	client := fake.NewSimpleClientset()
+	o := NewOrchestrator(client)
+
+	var one int32 = 1
+	rs := []Resource{
+		{Name: "secret", Kind: "Secret", Spec: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kong-postgres", Namespace: owner.Namespace}}},
+		{Name: "deploy", Kind: "Deployment", Spec: &v1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "kong", Namespace: owner.Namespace}, Spec: v1beta1.DeploymentSpec{Replicas: &one}}},
+		// an unregistered kind forces the chain to fail after Secret and Deployment succeed
+		{Name: "bogus", Kind: "DoesNotExist", Spec: struct{}{}},
+	}
+
+	if err := o.Apply(context.Background(), owner, rs); err == nil {
+		t.Fatal("expected an error from the unregistered kind")
+	}
+
+	if _, err := client.CoreV1().Secrets(owner.Namespace).Get("kong-postgres", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the secret to be rolled back")
+	}
+	if _, err := client.ExtensionsV1beta1().Deployments(owner.Namespace).Get("kong", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected the deployment to be rolled back")
+	}
+}
@@ -0,0 +1,50 @@
+// Package resources provides a typed, ordered framework for reconciling the
+// plain Kubernetes objects (Service, Deployment, Secret, ConfigMap, Ingress,
+// HorizontalPodAutoscaler, StatefulSet, Job) a KongCluster owns, replacing
+// the hand-rolled Create*/Delete* functions k8sutil used to carry directly.
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// ResourcePlugin is the standard CRUD interface every managed resource kind implements
+type ResourcePlugin interface {
+	// Kind identifies the plugin, e.g. "Secret", "Deployment", "Service"
+	Kind() string
+	// DependsOn lists the Kinds that must be applied before this one, and
+	// torn down after it
+	DependsOn() []string
+	// Create creates spec and returns the resulting object's name
+	Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error)
+	// Get looks up the object named by spec, returning a k8serrors.IsNotFound error if absent
+	Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error)
+	// Update reconciles an existing object towards spec, returning its name
+	Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error)
+	// Delete removes the object named by spec. A not-found is not an error.
+	Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error
+}
+
+// Resource pairs a spec with the plugin Kind that should reconcile it, so
+// the orchestrator can apply several kinds (and several instances of the
+// same kind, e.g. the proxy and admin Services) in one pass
+type Resource struct {
+	// Name uniquely identifies this resource within the Apply/Teardown call
+	Name string
+	Kind string
+	Spec interface{}
+}
+
+// Status reports the reconcile outcome for a single Resource
+type Status struct {
+	Name    string
+	Created bool
+	Error   error
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s(%s)", r.Kind, r.Name)
+}
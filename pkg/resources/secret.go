@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// SecretPlugin reconciles a single Secret spec
+type SecretPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewSecretPlugin builds a SecretPlugin backed by client
+func NewSecretPlugin(client kubernetes.Interface) *SecretPlugin {
+	return &SecretPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *SecretPlugin) Kind() string { return "Secret" }
+
+// DependsOn satisfies ResourcePlugin; Secrets have no dependencies of their own
+func (p *SecretPlugin) DependsOn() []string { return nil }
+
+func (p *SecretPlugin) asSecret(spec interface{}) (*v1.Secret, error) {
+	s, ok := spec.(*v1.Secret)
+	if !ok {
+		return nil, fmt.Errorf("SecretPlugin: unexpected spec type %T", spec)
+	}
+	return s, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *SecretPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	s, err := p.asSecret(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.CoreV1().Secrets(owner.Namespace).Create(s)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *SecretPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	s, err := p.asSecret(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.CoreV1().Secrets(owner.Namespace).Get(s.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin
+func (p *SecretPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	s, err := p.asSecret(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.CoreV1().Secrets(owner.Namespace).Get(s.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	s.ResourceVersion = existing.ResourceVersion
+
+	updated, err := p.client.CoreV1().Secrets(owner.Namespace).Update(s)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *SecretPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	s, err := p.asSecret(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.CoreV1().Secrets(owner.Namespace).Delete(s.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
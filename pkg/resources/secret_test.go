@@ -0,0 +1,69 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(user string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong-postgres", Namespace: owner.Namespace},
+			StringData: map[string]string{"KONG_PG_USER": user},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewSecretPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec("kong"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-postgres" {
+			t.Fatalf("expected name kong-postgres, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("kong"))
+		p := NewSecretPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("kong")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec("kong"))
+		p := NewSecretPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec("rotated-user")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.CoreV1().Secrets(owner.Namespace).Get("kong-postgres", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.StringData["KONG_PG_USER"] != "rotated-user" {
+			t.Fatalf("expected drift to be corrected, got %s", updated.StringData["KONG_PG_USER"])
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewSecretPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec("kong")); err != nil {
+			t.Fatalf("expected delete of missing secret to be a no-op, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// ServicePlugin reconciles a single Service spec
+type ServicePlugin struct {
+	client kubernetes.Interface
+}
+
+// NewServicePlugin builds a ServicePlugin backed by client
+func NewServicePlugin(client kubernetes.Interface) *ServicePlugin {
+	return &ServicePlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *ServicePlugin) Kind() string { return "Service" }
+
+// DependsOn satisfies ResourcePlugin; Services route to Pods backed by a Deployment
+func (p *ServicePlugin) DependsOn() []string { return []string{"Deployment"} }
+
+func (p *ServicePlugin) asService(spec interface{}) (*v1.Service, error) {
+	svc, ok := spec.(*v1.Service)
+	if !ok {
+		return nil, fmt.Errorf("ServicePlugin: unexpected spec type %T", spec)
+	}
+	return svc, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *ServicePlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	svc, err := p.asService(spec)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := hashSpec(svc.Spec)
+	if err != nil {
+		return "", err
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[SpecHashAnnotation] = hash
+
+	created, err := p.client.CoreV1().Services(owner.Namespace).Create(svc)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *ServicePlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	svc, err := p.asService(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.CoreV1().Services(owner.Namespace).Get(svc.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin. Like DeploymentPlugin, it only patches the
+// Service when the hash of the desired spec (protocol, ports, type,
+// LoadBalancerSourceRanges, ...) differs from the hash already recorded on
+// it, so a no-op reconcile never re-patches a Service that hasn't drifted.
+func (p *ServicePlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	svc, err := p.asService(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.CoreV1().Services(owner.Namespace).Get(svc.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	// Hash before ClusterIP is filled in below: ClusterIP is assigned by the
+	// API server, not operator-managed, and Create never has one to hash, so
+	// including it here would make every first Update after Create look
+	// like drift.
+	desiredHash, err := hashSpec(svc.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	if existing.Annotations[SpecHashAnnotation] == desiredHash {
+		return existing.Name, nil
+	}
+
+	svc.Spec.ClusterIP = existing.Spec.ClusterIP
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[SpecHashAnnotation] = desiredHash
+
+	patch, err := json.Marshal(svc)
+	if err != nil {
+		return "", err
+	}
+
+	updated, err := p.client.CoreV1().Services(owner.Namespace).Patch(svc.Name, types.StrategicMergePatchType, patch)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *ServicePlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	svc, err := p.asService(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.CoreV1().Services(owner.Namespace).Delete(svc.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+func testOwner() *tpr.KongCluster {
+	return &tpr.KongCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-kong", Namespace: "default"},
+	}
+}
+
+func TestServicePlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(port int32) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong-proxy", Namespace: owner.Namespace},
+			Spec: v1.ServiceSpec{
+				Ports: []v1.ServicePort{{Name: "kong-proxy", Port: port}},
+			},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewServicePlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec(80))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-proxy" {
+			t.Fatalf("expected name kong-proxy, got %s", name)
+		}
+
+		if _, err := client.CoreV1().Services(owner.Namespace).Get("kong-proxy", metav1.GetOptions{}); err != nil {
+			t.Fatalf("expected service to exist: %v", err)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(80))
+		p := NewServicePlugin(client)
+
+		name, err := p.Update(context.Background(), owner, newSpec(80))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-proxy" {
+			t.Fatalf("expected name kong-proxy, got %s", name)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(80))
+		p := NewServicePlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(8080)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.CoreV1().Services(owner.Namespace).Get("kong-proxy", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.Spec.Ports[0].Port != 8080 {
+			t.Fatalf("expected drift to be corrected to port 8080, got %d", updated.Spec.Ports[0].Port)
+		}
+	})
+
+	t.Run("skips patch when no drift", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewServicePlugin(client)
+
+		if _, err := p.Create(context.Background(), owner, newSpec(80)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		before, err := client.CoreV1().Services(owner.Namespace).Get("kong-proxy", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := p.Update(context.Background(), owner, newSpec(80)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		after, err := client.CoreV1().Services(owner.Namespace).Get("kong-proxy", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if before.ResourceVersion != after.ResourceVersion {
+			t.Fatalf("expected no-op update to leave the Service untouched, resource version changed from %s to %s", before.ResourceVersion, after.ResourceVersion)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewServicePlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec(80)); err != nil {
+			t.Fatalf("expected delete of missing service to be a no-op, got: %v", err)
+		}
+	})
+}
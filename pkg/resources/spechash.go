@@ -0,0 +1,24 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// SpecHashAnnotation marks a managed object with the hash of the
+// operator-managed fields that were last applied to it, so Update can tell
+// real drift apart from a no-op re-apply and only trigger a restart when
+// something actually changed.
+const SpecHashAnnotation = "kong-operator/spec-hash"
+
+// hashSpec returns a stable hash of spec's JSON encoding
+func hashSpec(spec interface{}) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,48 @@
+package resources
+
+import "testing"
+
+func TestHashSpec(t *testing.T) {
+	type podSpec struct {
+		Image    string
+		Replicas int32
+	}
+
+	t.Run("stable for equal input", func(t *testing.T) {
+		a, err := hashSpec(podSpec{Image: "kong:0.13", Replicas: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		b, err := hashSpec(podSpec{Image: "kong:0.13", Replicas: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if a != b {
+			t.Fatalf("expected equal specs to hash the same, got %s != %s", a, b)
+		}
+	})
+
+	t.Run("differs for different input", func(t *testing.T) {
+		a, err := hashSpec(podSpec{Image: "kong:0.13", Replicas: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		b, err := hashSpec(podSpec{Image: "kong:0.13", Replicas: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if a == b {
+			t.Fatalf("expected differing specs to hash differently")
+		}
+	})
+
+	t.Run("errors on unmarshalable input", func(t *testing.T) {
+		if _, err := hashSpec(func() {}); err == nil {
+			t.Fatal("expected an error marshaling a func value")
+		}
+	})
+}
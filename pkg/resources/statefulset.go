@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/upmc-enterprises/kong-operator/pkg/tpr"
+)
+
+// StatefulSetPlugin reconciles a single StatefulSet spec
+type StatefulSetPlugin struct {
+	client kubernetes.Interface
+}
+
+// NewStatefulSetPlugin builds a StatefulSetPlugin backed by client
+func NewStatefulSetPlugin(client kubernetes.Interface) *StatefulSetPlugin {
+	return &StatefulSetPlugin{client: client}
+}
+
+// Kind satisfies ResourcePlugin
+func (p *StatefulSetPlugin) Kind() string { return "StatefulSet" }
+
+// DependsOn satisfies ResourcePlugin; StatefulSets have no dependencies of their own
+func (p *StatefulSetPlugin) DependsOn() []string { return nil }
+
+func (p *StatefulSetPlugin) asStatefulSet(spec interface{}) (*appsv1beta1.StatefulSet, error) {
+	s, ok := spec.(*appsv1beta1.StatefulSet)
+	if !ok {
+		return nil, fmt.Errorf("StatefulSetPlugin: unexpected spec type %T", spec)
+	}
+	return s, nil
+}
+
+// Create satisfies ResourcePlugin
+func (p *StatefulSetPlugin) Create(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	s, err := p.asStatefulSet(spec)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.client.AppsV1beta1().StatefulSets(owner.Namespace).Create(s)
+	if err != nil {
+		return "", err
+	}
+
+	return created.Name, nil
+}
+
+// Get satisfies ResourcePlugin
+func (p *StatefulSetPlugin) Get(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (interface{}, error) {
+	s, err := p.asStatefulSet(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.AppsV1beta1().StatefulSets(owner.Namespace).Get(s.Name, metav1.GetOptions{})
+}
+
+// Update satisfies ResourcePlugin
+func (p *StatefulSetPlugin) Update(ctx context.Context, owner *tpr.KongCluster, spec interface{}) (string, error) {
+	s, err := p.asStatefulSet(spec)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := p.client.AppsV1beta1().StatefulSets(owner.Namespace).Get(s.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	s.ResourceVersion = existing.ResourceVersion
+
+	updated, err := p.client.AppsV1beta1().StatefulSets(owner.Namespace).Update(s)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Name, nil
+}
+
+// Delete satisfies ResourcePlugin
+func (p *StatefulSetPlugin) Delete(ctx context.Context, owner *tpr.KongCluster, spec interface{}) error {
+	s, err := p.asStatefulSet(spec)
+	if err != nil {
+		return err
+	}
+
+	err = p.client.AppsV1beta1().StatefulSets(owner.Namespace).Delete(s.Name, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStatefulSetPlugin(t *testing.T) {
+	owner := testOwner()
+
+	newSpec := func(replicas int32) *appsv1beta1.StatefulSet {
+		return &appsv1beta1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kong-postgres", Namespace: owner.Namespace},
+			Spec: appsv1beta1.StatefulSetSpec{
+				ServiceName: "kong-postgres",
+				Replicas:    &replicas,
+			},
+		}
+	}
+
+	t.Run("create when missing", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewStatefulSetPlugin(client)
+
+		name, err := p.Create(context.Background(), owner, newSpec(1))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if name != "kong-postgres" {
+			t.Fatalf("expected name kong-postgres, got %s", name)
+		}
+	})
+
+	t.Run("idempotent re-apply", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(1))
+		p := NewStatefulSetPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(1)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("drift correction", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newSpec(1))
+		p := NewStatefulSetPlugin(client)
+
+		if _, err := p.Update(context.Background(), owner, newSpec(3)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated, err := client.AppsV1beta1().StatefulSets(owner.Namespace).Get("kong-postgres", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *updated.Spec.Replicas != 3 {
+			t.Fatalf("expected drift to be corrected to 3, got %d", *updated.Spec.Replicas)
+		}
+	})
+
+	t.Run("not-found on delete", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		p := NewStatefulSetPlugin(client)
+
+		if err := p.Delete(context.Background(), owner, newSpec(1)); err != nil {
+			t.Fatalf("expected delete of missing statefulset to be a no-op, got: %v", err)
+		}
+	})
+}
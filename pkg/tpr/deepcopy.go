@@ -0,0 +1,130 @@
+package tpr
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies every field of c into out, deep-copying its slices and
+// maps so out never aliases c's backing storage
+func (c *KongCluster) DeepCopyInto(out *KongCluster) {
+	out.TypeMeta = c.TypeMeta
+	out.ObjectMeta = *c.ObjectMeta.DeepCopy()
+	out.Type = c.Type
+	c.Spec.DeepCopyInto(&out.Spec)
+	c.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of c, so callers that mutate it (e.g. an
+// informer event handler) never alias the informer's cached object
+func (c *KongCluster) DeepCopy() *KongCluster {
+	if c == nil {
+		return nil
+	}
+	out := new(KongCluster)
+	c.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject satisfies runtime.Object
+func (c *KongCluster) DeepCopyObject() runtime.Object {
+	return c.DeepCopy()
+}
+
+// DeepCopyInto copies every field of spec into out, deep-copying its slices
+// so out never aliases spec's backing arrays
+func (spec *KongClusterSpec) DeepCopyInto(out *KongClusterSpec) {
+	*out = *spec
+
+	if spec.Replicas != nil {
+		replicas := *spec.Replicas
+		out.Replicas = &replicas
+	}
+
+	out.Services = copyKongAdminObjects(spec.Services)
+	out.Routes = copyKongAdminObjects(spec.Routes)
+	out.Plugins = copyKongAdminObjects(spec.Plugins)
+	out.Consumers = copyKongAdminObjects(spec.Consumers)
+	out.Upstreams = copyKongAdminObjects(spec.Upstreams)
+	out.Certificates = copyKongAdminObjects(spec.Certificates)
+
+	if spec.ProxyLoadBalancerSourceRanges != nil {
+		out.ProxyLoadBalancerSourceRanges = append([]string(nil), spec.ProxyLoadBalancerSourceRanges...)
+	}
+}
+
+// copyKongAdminObjects deep-copies a []KongAdminObject, including each
+// object's Config map
+func copyKongAdminObjects(in []KongAdminObject) []KongAdminObject {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]KongAdminObject, len(in))
+	for i, o := range in {
+		out[i].Name = o.Name
+		if o.Config != nil {
+			out[i].Config = make(map[string]interface{}, len(o.Config))
+			for k, v := range o.Config {
+				out[i].Config[k] = deepCopyJSONValue(v)
+			}
+		}
+	}
+	return out
+}
+
+// deepCopyJSONValue deep-copies a value of the kind encoding/json produces
+// when unmarshaling into interface{} (map[string]interface{},
+// []interface{}, or an immutable primitive), so a copied KongAdminObject
+// never aliases the source Config's nested maps/slices
+func deepCopyJSONValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			out[k] = deepCopyJSONValue(e)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, e := range val {
+			out[i] = deepCopyJSONValue(e)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// DeepCopyInto copies every field of status into out, deep-copying its map
+// and slice fields so out never aliases status's backing storage
+func (status *KongClusterStatus) DeepCopyInto(out *KongClusterStatus) {
+	*out = *status
+
+	if status.AdminObjects != nil {
+		out.AdminObjects = make(map[string]KongAdminObjectStatus, len(status.AdminObjects))
+		for k, v := range status.AdminObjects {
+			out.AdminObjects[k] = v
+		}
+	}
+
+	if status.Conditions != nil {
+		out.Conditions = append([]KongClusterCondition(nil), status.Conditions...)
+	}
+}
+
+// DeepCopyObject satisfies runtime.Object
+func (cl *KongClusterList) DeepCopyObject() runtime.Object {
+	if cl == nil {
+		return nil
+	}
+	out := new(KongClusterList)
+	out.TypeMeta = cl.TypeMeta
+	out.ListMeta = cl.ListMeta
+	if cl.Items != nil {
+		out.Items = make([]KongCluster, len(cl.Items))
+		for i := range cl.Items {
+			cl.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+	return out
+}
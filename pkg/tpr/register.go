@@ -0,0 +1,100 @@
+package tpr
+
+import (
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SchemeGroupVersion is the group/version used to register the KongCluster types
+var SchemeGroupVersion = schema.GroupVersion{Group: CRDGroup, Version: CRDVersion}
+
+// SchemeBuilder registers the KongCluster types with a runtime.Scheme
+var SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+// AddToScheme adds the KongCluster types to the given scheme
+var AddToScheme = SchemeBuilder.AddToScheme
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(
+		SchemeGroupVersion,
+		&KongCluster{},
+		&KongClusterList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// kongAdminObjectArraySchema builds the schema for a []KongAdminObject spec
+// field (services/routes/plugins/consumers/upstreams/certificates). Config
+// is left schemaless since each Kong entity has its own type-specific
+// fields, and the admin API - not the CRD - is what actually validates them.
+func kongAdminObjectArraySchema() apiextensionsv1beta1.JSONSchemaProps {
+	return apiextensionsv1beta1.JSONSchemaProps{
+		Type: "array",
+		Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{
+			Schema: &apiextensionsv1beta1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+					"name":   {Type: "string"},
+					"config": {Type: "object"},
+				},
+			},
+		},
+	}
+}
+
+// CustomResourceDefinition builds the CustomResourceDefinition object used to
+// register the KongCluster kind with the apiextensions API.
+func CustomResourceDefinition() *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: CRDFullName,
+		},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   CRDGroup,
+			Version: CRDVersion,
+			Scope:   apiextensionsv1beta1.NamespaceScoped,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Plural: CRDKindPlural,
+				Kind:   CRDKind,
+			},
+			Subresources: &apiextensionsv1beta1.CustomResourceSubresources{
+				Status: &apiextensionsv1beta1.CustomResourceSubresourceStatus{},
+			},
+			Validation: &apiextensionsv1beta1.CustomResourceValidation{
+				OpenAPIV3Schema: &apiextensionsv1beta1.JSONSchemaProps{
+					Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+						"spec": {
+							Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+								"baseImage": {Type: "string"},
+								"replicas":  {Type: "integer"},
+								"postgres": {
+									Type: "object",
+									Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+										"manage":      {Type: "boolean"},
+										"image":       {Type: "string"},
+										"storageSize": {Type: "string"},
+									},
+								},
+								"services":     kongAdminObjectArraySchema(),
+								"routes":       kongAdminObjectArraySchema(),
+								"plugins":      kongAdminObjectArraySchema(),
+								"consumers":    kongAdminObjectArraySchema(),
+								"upstreams":    kongAdminObjectArraySchema(),
+								"certificates": kongAdminObjectArraySchema(),
+								"proxyLoadBalancerSourceRanges": {
+									Type: "array",
+									Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{
+										Schema: &apiextensionsv1beta1.JSONSchemaProps{Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
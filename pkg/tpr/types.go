@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2017, UPMC Enterprises
+All rights reserved.
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+    * Redistributions of source code must retain the above copyright
+      notice, this list of conditions and the following disclaimer.
+    * Redistributions in binary form must reproduce the above copyright
+      notice, this list of conditions and the following disclaimer in the
+      documentation and/or other materials provided with the distribution.
+    * Neither the name UPMC Enterprises nor the
+      names of its contributors may be used to endorse or promote products
+      derived from this software without specific prior written permission.
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+DISCLAIMED. IN NO EVENT SHALL UPMC ENTERPRISES BE LIABLE FOR ANY
+DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+(INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+(INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+*/
+
+package tpr
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CRDKind is the Kind of the KongCluster custom resource
+	CRDKind = "KongCluster"
+	// CRDKindPlural is the plural form of CRDKind
+	CRDKindPlural = "kongclusters"
+	// CRDGroup is the API group the KongCluster CRD is registered under
+	CRDGroup = "enterprises.upmc.com"
+	// CRDVersion is the API version the KongCluster CRD is registered under
+	CRDVersion = "v1"
+	// CRDFullName is the fully qualified name of the CRD object in the cluster
+	CRDFullName = CRDKindPlural + "." + CRDGroup
+)
+
+// KongCluster defines a Kong cluster managed by the operator
+type KongCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KongClusterSpec   `json:"spec"`
+	Status KongClusterStatus `json:"status,omitempty"`
+
+	// Type carries the watch event type (ADDED/MODIFIED/DELETED) that produced
+	// this object. It is populated by MonitorKongEvents and is never persisted.
+	Type string `json:"-"`
+}
+
+// KongClusterSpec defines the desired state of a KongCluster
+type KongClusterSpec struct {
+	// BaseImage is the Kong container image to run
+	BaseImage string `json:"baseImage"`
+	// Replicas is the desired number of Kong replicas
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Services are the Kong services to reconcile against the admin API
+	Services []KongAdminObject `json:"services,omitempty"`
+	// Routes are the Kong routes to reconcile against the admin API
+	Routes []KongAdminObject `json:"routes,omitempty"`
+	// Plugins are the Kong plugins to reconcile against the admin API
+	Plugins []KongAdminObject `json:"plugins,omitempty"`
+	// Consumers are the Kong consumers to reconcile against the admin API
+	Consumers []KongAdminObject `json:"consumers,omitempty"`
+	// Upstreams are the Kong upstreams to reconcile against the admin API
+	Upstreams []KongAdminObject `json:"upstreams,omitempty"`
+	// Certificates are the Kong certificates to reconcile against the admin API
+	Certificates []KongAdminObject `json:"certificates,omitempty"`
+
+	// Postgres configures the Postgres backend the Kong deployment connects to
+	Postgres PostgresSpec `json:"postgres,omitempty"`
+
+	// ProxyLoadBalancerSourceRanges restricts which CIDRs may reach the
+	// kong-proxy Service's LoadBalancer. Defaults to []string{"0.0.0.0/0"}
+	// when empty.
+	ProxyLoadBalancerSourceRanges []string `json:"proxyLoadBalancerSourceRanges,omitempty"`
+}
+
+// PostgresSpec configures the Postgres backend a KongCluster connects to
+type PostgresSpec struct {
+	// Manage tells the operator to generate the kong-postgres Secret and
+	// provision a backing Postgres StatefulSet when they don't already
+	// exist. When false, the Secret is assumed to be provisioned out of band.
+	Manage bool `json:"manage,omitempty"`
+	// Image is the Postgres container image to run when Manage is true.
+	// Defaults to defaultPostgresImage.
+	Image string `json:"image,omitempty"`
+	// StorageSize is the size of the PersistentVolumeClaim requested for the
+	// Postgres StatefulSet when Manage is true. Defaults to defaultPostgresStorageSize.
+	StorageSize string `json:"storageSize,omitempty"`
+}
+
+// KongAdminObject is a declarative description of a single object (service,
+// route, plugin, consumer, upstream or certificate) to reconcile against the
+// Kong admin API. Config carries the object's type-specific fields verbatim,
+// since each Kong entity has its own shape.
+type KongAdminObject struct {
+	// Name uniquely identifies this object within its kind
+	Name string `json:"name"`
+	// Config holds the object's admin-API fields, e.g. "url", "host",
+	// "strip_path", "methods". Values are typed (string/bool/number/array/
+	// object) rather than forced to string, since Kong's schema validation
+	// rejects most non-string fields encoded as strings.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// KongClusterStatus defines the observed state of a KongCluster
+type KongClusterStatus struct {
+	// AdminObjects reports the reconcile state of every object declared
+	// under Spec.Services/Routes/Plugins/Consumers/Upstreams/Certificates,
+	// keyed by "<kind>/<name>"
+	AdminObjects map[string]KongAdminObjectStatus `json:"adminObjects,omitempty"`
+
+	// Postgres reports the state of the Postgres credential lifecycle
+	Postgres PostgresStatus `json:"postgres,omitempty"`
+
+	// Conditions reports the overall reconcile result in the standard
+	// Kubernetes condition shape
+	Conditions []KongClusterCondition `json:"conditions,omitempty"`
+}
+
+// KongClusterConditionType is a type of condition reported on a KongCluster's status
+type KongClusterConditionType string
+
+const (
+	// ConditionProgressing is true while a reconcile is actively applying
+	// the KongCluster spec
+	ConditionProgressing KongClusterConditionType = "Progressing"
+	// ConditionAvailable is true when the kong-proxy/kong-admin Services,
+	// the kong Deployment and the declarative admin config all reconciled
+	// successfully
+	ConditionAvailable KongClusterConditionType = "Available"
+	// ConditionDegraded is true when the last reconcile attempt failed to
+	// apply one or more of the managed resources
+	ConditionDegraded KongClusterConditionType = "Degraded"
+)
+
+// KongClusterCondition is a single condition in a KongCluster's status, in
+// the standard Kubernetes condition shape
+type KongClusterCondition struct {
+	Type               KongClusterConditionType `json:"type"`
+	Status             v1.ConditionStatus       `json:"status"`
+	Reason             string                   `json:"reason,omitempty"`
+	Message            string                   `json:"message,omitempty"`
+	LastTransitionTime metav1.Time              `json:"lastTransitionTime,omitempty"`
+}
+
+// SetCondition upserts a condition of type condType into status.Conditions:
+// an existing condition of the same type has its fields updated in place,
+// with LastTransitionTime only advancing when Status actually changes;
+// otherwise a new condition is appended
+func (status *KongClusterStatus) SetCondition(condType KongClusterConditionType, conditionStatus v1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+
+	for i := range status.Conditions {
+		c := &status.Conditions[i]
+		if c.Type != condType {
+			continue
+		}
+
+		if c.Status != conditionStatus {
+			c.LastTransitionTime = now
+		}
+		c.Status = conditionStatus
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+
+	status.Conditions = append(status.Conditions, KongClusterCondition{
+		Type:               condType,
+		Status:             conditionStatus,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+}
+
+// PostgresStatus reports the state of the Postgres credential lifecycle
+type PostgresStatus struct {
+	// SecretReady is true once the kong-postgres Secret exists
+	SecretReady bool `json:"secretReady"`
+	// MigrationsApplied is true once the kong migrations bootstrap Job has completed
+	MigrationsApplied bool `json:"migrationsApplied"`
+	// RotatedAt mirrors the kong-postgres Secret's rotated-at annotation that
+	// was last applied, so repeated reconciles don't re-rotate
+	RotatedAt string `json:"rotatedAt,omitempty"`
+	// Error carries the last error encountered managing Postgres, if any
+	Error string `json:"error,omitempty"`
+}
+
+// KongAdminObjectStatus reports the last reconcile result for a single
+// KongAdminObject
+type KongAdminObjectStatus struct {
+	Kind         string      `json:"kind"`
+	Name         string      `json:"name"`
+	Applied      bool        `json:"applied"`
+	Error        string      `json:"error,omitempty"`
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// KongClusterList is a list of KongCluster resources
+type KongClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KongCluster `json:"items"`
+}